@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
@@ -16,29 +17,46 @@ import (
 	"github.com/algolia/mcp/pkg/abtesting"
 	"github.com/algolia/mcp/pkg/analytics"
 	"github.com/algolia/mcp/pkg/collections"
+	"github.com/algolia/mcp/pkg/dictionaries"
+	"github.com/algolia/mcp/pkg/insights"
+	"github.com/algolia/mcp/pkg/keys"
+	"github.com/algolia/mcp/pkg/mcm"
 	"github.com/algolia/mcp/pkg/monitoring"
 	"github.com/algolia/mcp/pkg/querysuggestions"
 	"github.com/algolia/mcp/pkg/recommend"
 	searchpkg "github.com/algolia/mcp/pkg/search"
+	"github.com/algolia/mcp/pkg/security"
 	"github.com/algolia/mcp/pkg/usage"
 
 	"github.com/mark3labs/mcp-go/server"
 )
 
 func main() {
+	// Subcommands run once and exit instead of starting the server
+	if len(os.Args) > 1 && os.Args[1] == "relevance" {
+		os.Exit(runRelevance(os.Args[2:]))
+	}
+
 	// Create a new MCP server with name and version
 	mcps := server.NewMCPServer("Algolia MCP", "0.0.2")
 
 	// Parse MCP_ENABLED_TOOLS environment variable to determine which toolsets to enable
 	enabledToolsEnv := os.Getenv("MCP_ENABLED_TOOLS")
 	enabled := make(map[string]bool)
-	allTools := []string{"abtesting", "analytics", "collections", "monitoring", "querysuggestions", "recommend", "search", "search_read", "search_write", "usage"}
+	allTools := []string{"abtesting", "analytics", "collections", "dictionaries", "insights", "keys", "keys_read", "mcm", "monitoring", "querysuggestions", "recommend", "search", "search_read", "search_write", "security", "usage"}
+
+	// Toolsets that can create or delete credentials, restrict access to the
+	// application, delete user data, or that need a specific plan, are only
+	// enabled when listed explicitly
+	optInTools := []string{"insights", "keys", "mcm", "security"}
 
 	// If MCP_ENABLED_TOOLS is set, enable only the specified toolsets
-	// Otherwise, enable all toolsets
+	// Otherwise, enable all toolsets except the opt-in ones
 	if enabledToolsEnv == "" {
 		for _, toolName := range allTools {
-			enabled[toolName] = true
+			if !slices.Contains(optInTools, toolName) {
+				enabled[toolName] = true
+			}
 		}
 	}
 
@@ -82,6 +100,24 @@ func main() {
 	if enabled["collections"] {
 		collections.RegisterTools(mcps)
 	}
+	if enabled["dictionaries"] {
+		dictionaries.RegisterAll(mcps)
+	}
+	if enabled["insights"] {
+		insights.RegisterAll(mcps)
+	}
+	if enabled["keys"] {
+		keys.RegisterAll(mcps)
+	} else if enabled["keys_read"] {
+		// Only register the read-only key tools if "keys" is not enabled
+		keys.RegisterReadAll(mcps)
+	}
+	if enabled["mcm"] {
+		mcm.RegisterAll(mcps)
+	}
+	if enabled["security"] {
+		security.RegisterAll(mcps)
+	}
 	if enabled["monitoring"] {
 		monitoring.RegisterTools(mcps)
 	}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/search/relevance"
+)
+
+// runRelevance implements the "relevance" subcommand, which runs a relevance
+// test suite from the command line and exits non-zero on failures or regressions.
+func runRelevance(args []string) int {
+	fs := flag.NewFlagSet("relevance", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: mcp relevance [flags] <suite.yaml|suite.json>")
+		fs.PrintDefaults()
+	}
+	indexName := fs.String("index", os.Getenv("ALGOLIA_INDEX_NAME"), "index to run the suite against (overrides the suite's indexName)")
+	compare := fs.String("compare", "", "second index to run the suite against and diff rankings with")
+	asJSON := fs.Bool("json", false, "print the full report as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	s, err := relevance.LoadSuite(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if *indexName != "" && (s.IndexName == "" || isFlagSet(fs, "index")) {
+		s.IndexName = *indexName
+	}
+	if *compare != "" {
+		s.CompareIndexName = *compare
+	}
+	if s.IndexName == "" {
+		fmt.Fprintln(os.Stderr, "no index to run the suite against: set indexName in the suite, -index or ALGOLIA_INDEX_NAME")
+		return 2
+	}
+
+	client := search.NewClient(os.Getenv("ALGOLIA_APP_ID"), os.Getenv("ALGOLIA_API_KEY"))
+	report := relevance.Run(client, s)
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	} else {
+		printRelevanceReport(report)
+	}
+
+	if report.Failed > 0 || report.Regressions > 0 {
+		return 1
+	}
+	return 0
+}
+
+func printRelevanceReport(report relevance.Report) {
+	for _, c := range report.Cases {
+		label := c.Name
+		if label == "" {
+			label = fmt.Sprintf("%q", c.Query)
+		}
+		status := "PASS"
+		if !c.Passed {
+			status = "FAIL"
+		}
+		fmt.Printf("%s %s [%s]\n", status, label, c.IndexName)
+		printCaseDetails(c.Error, c.Assertions)
+
+		if cmp := c.Compare; cmp != nil {
+			status := "PASS"
+			switch {
+			case cmp.Regression:
+				status = "REGRESSION"
+			case !cmp.Passed:
+				status = "FAIL"
+			}
+			fmt.Printf("  %s on %s\n", status, cmp.IndexName)
+			printCaseDetails(cmp.Error, cmp.Assertions)
+			for _, id := range cmp.Diff.Removed {
+				fmt.Printf("    - %s\n", id)
+			}
+			for _, id := range cmp.Diff.Added {
+				fmt.Printf("    + %s\n", id)
+			}
+			for _, m := range cmp.Diff.Moved {
+				fmt.Printf("    ~ %s %d -> %d\n", m.ObjectID, m.From, m.To)
+			}
+		}
+	}
+
+	fmt.Printf("\n%d passed, %d failed", report.Passed, report.Failed)
+	if report.CompareIndexName != "" {
+		fmt.Printf(", %d regressions on %s", report.Regressions, report.CompareIndexName)
+	}
+	fmt.Println()
+}
+
+func printCaseDetails(errMsg string, assertions []relevance.AssertionResult) {
+	if errMsg != "" {
+		fmt.Printf("    error: %s\n", errMsg)
+	}
+	for _, a := range assertions {
+		if !a.Passed {
+			fmt.Printf("    %s\n", a.Detail)
+		}
+	}
+}
+
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
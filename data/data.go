@@ -0,0 +1,9 @@
+// Package data embeds the API specifications the tools are built from.
+package data
+
+import _ "embed"
+
+// SearchSpec is the OpenAPI specification of the Search API.
+//
+//go:embed search.json
+var SearchSpec []byte
@@ -5,6 +5,7 @@ go 1.24.1
 require (
 	github.com/algolia/algoliasearch-client-go/v3 v3.31.4
 	github.com/mark3labs/mcp-go v0.24.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -1,6 +1,17 @@
 package abtesting
 
-import "github.com/mark3labs/mcp-go/server"
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/mcp/pkg/algoliautil"
+)
+
+// apiHost is the host of the A/B testing API.
+const apiHost = "https://analytics.algolia.com"
 
 // RegisterTools aggregates all abtesting tool registrations.
 func RegisterTools(mcps *server.MCPServer) {
@@ -11,4 +22,53 @@ func RegisterTools(mcps *server.MCPServer) {
 	RegisterStopABTest(mcps)
 	RegisterEstimateABTest(mcps)
 	RegisterScheduleABTest(mcps)
+	RegisterAnalyzeABTest(mcps)
+	RegisterCreateFromSettings(mcps)
+	RegisterPromoteWinner(mcps)
+}
+
+// ABTest is an A/B test with the statistics of its variants, as returned by
+// the A/B testing API.
+type ABTest struct {
+	ABTestID      int            `json:"abTestID"`
+	Name          string         `json:"name"`
+	Status        string         `json:"status"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+	EndAt         time.Time      `json:"endAt"`
+	Variants      []Variant      `json:"variants"`
+	Configuration map[string]any `json:"configuration,omitempty"`
+}
+
+// Variant is a variant of an A/B test. The first variant is the control.
+type Variant struct {
+	Index                  string              `json:"index"`
+	Description            string              `json:"description"`
+	TrafficPercentage      int                 `json:"trafficPercentage"`
+	SearchCount            int                 `json:"searchCount"`
+	TrackedSearchCount     int                 `json:"trackedSearchCount"`
+	UserCount              int                 `json:"userCount"`
+	TrackedUserCount       int                 `json:"trackedUserCount"`
+	ClickCount             int                 `json:"clickCount"`
+	ConversionCount        int                 `json:"conversionCount"`
+	AddToCartCount         int                 `json:"addToCartCount"`
+	PurchaseCount          int                 `json:"purchaseCount"`
+	Currencies             map[string]Currency `json:"currencies"`
+	CustomSearchParameters map[string]any      `json:"customSearchParameters,omitempty"`
+}
+
+// Currency is the revenue of a variant in a currency.
+type Currency struct {
+	Currency          string  `json:"currency"`
+	Revenue           float64 `json:"revenue"`
+	Mean              float64 `json:"mean"`
+	StandardDeviation float64 `json:"standardDeviation"`
+}
+
+// getABTest returns an A/B test with all the statistics of its variants,
+// some of which the Go API client doesn't decode.
+func getABTest(id int) (ABTest, error) {
+	var res ABTest
+	err := algoliautil.Request(apiHost, "ALGOLIA_API_KEY", http.MethodGet, fmt.Sprintf("/2/abtests/%d", id), nil, &res)
+	return res, err
 }
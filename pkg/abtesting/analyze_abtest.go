@@ -0,0 +1,391 @@
+package abtesting
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultConfidence is the confidence level used when none is given.
+	defaultConfidence = 0.95
+	// power is the probability of detecting an effect when there is one,
+	// used to estimate the sample size needed.
+	power = 0.8
+	// srmThreshold is the p-value below which the traffic split is considered
+	// to mismatch the configured trafficPercentage.
+	srmThreshold = 0.001
+	// minTrackedSearches is the number of tracked searches per variant below
+	// which results aren't interpreted.
+	minTrackedSearches = 100
+)
+
+// Recommendations.
+const (
+	ActionKeepRunning = "keep_running"
+	ActionStop        = "stop"
+	ActionWinner      = "winner"
+)
+
+// rateMetrics are the metrics computed as a number of events per tracked search.
+var rateMetrics = []struct {
+	name  string
+	count func(Variant) int
+}{
+	{"clickThroughRate", func(v Variant) int { return v.ClickCount }},
+	{"conversionRate", func(v Variant) int { return v.ConversionCount }},
+	{"addToCartRate", func(v Variant) int { return v.AddToCartCount }},
+	{"purchaseRate", func(v Variant) int { return v.PurchaseCount }},
+}
+
+// MetricAnalysis compares a metric of a variant with the control.
+type MetricAnalysis struct {
+	Metric  string  `json:"metric"`
+	Control float64 `json:"control"`
+	Variant float64 `json:"variant"`
+	// Lift and its confidence interval are relative to the control value.
+	Lift          *float64 `json:"lift"`
+	LiftLow       *float64 `json:"liftLow"`
+	LiftHigh      *float64 `json:"liftHigh"`
+	PValue        *float64 `json:"pValue"`
+	Significant   bool     `json:"significant"`
+	SampleSize    *int     `json:"sampleSizeNeeded"`
+	DaysRemaining *float64 `json:"daysRemaining,omitempty"`
+}
+
+// VariantAnalysis is the analysis of a variant against the control.
+type VariantAnalysis struct {
+	Index             string           `json:"index"`
+	Description       string           `json:"description,omitempty"`
+	TrafficPercentage int              `json:"trafficPercentage"`
+	Users             int              `json:"users"`
+	TrackedSearches   int              `json:"trackedSearches"`
+	Metrics           []MetricAnalysis `json:"metrics,omitempty"`
+}
+
+// SampleRatio checks the traffic split against the configured percentages.
+type SampleRatio struct {
+	Expected  []float64 `json:"expected"`
+	Observed  []int     `json:"observed"`
+	ChiSquare float64   `json:"chiSquare"`
+	PValue    float64   `json:"pValue"`
+	Mismatch  bool      `json:"mismatch"`
+}
+
+// Recommendation is what to do with an A/B test.
+type Recommendation struct {
+	Action string `json:"action"`
+	Winner string `json:"winner,omitempty"`
+	// WinnerVariant is the position of the winner in the variants, since
+	// both variants can use the same index.
+	WinnerVariant *int   `json:"winnerVariant,omitempty"`
+	Reason        string `json:"reason"`
+}
+
+// Analysis is the outcome of abtesting_analyze.
+type Analysis struct {
+	ABTestID       int               `json:"abTestID"`
+	Name           string            `json:"name"`
+	Status         string            `json:"status"`
+	Confidence     float64           `json:"confidence"`
+	DaysElapsed    float64           `json:"daysElapsed"`
+	PrimaryMetric  string            `json:"primaryMetric"`
+	Variants       []VariantAnalysis `json:"variants"`
+	SampleRatio    *SampleRatio      `json:"sampleRatio,omitempty"`
+	Recommendation Recommendation    `json:"recommendation"`
+}
+
+// RegisterAnalyzeABTest registers the analyze_abtest tool with the MCP server.
+func RegisterAnalyzeABTest(mcps *server.MCPServer) {
+	analyzeABTestTool := mcp.NewTool(
+		"abtesting_analyze",
+		mcp.WithDescription("Analyze the results of an A/B test: lift, confidence interval and p-value of each variant against the control for click-through, conversion, add-to-cart and purchase rates and revenue, sample ratio mismatch against the configured traffic split, time remaining to reach significance, and a recommendation (keep running, stop or winner)"),
+		mcp.WithNumber(
+			"id",
+			mcp.Description("Unique A/B test identifier"),
+			mcp.Required(),
+		),
+		mcp.WithNumber(
+			"confidence",
+			mcp.Description("Confidence level of the intervals and significance (default: 0.95)"),
+		),
+		mcp.WithString(
+			"metric",
+			mcp.Description("Metric the recommendation is based on (defaults to the metric of the minimum detectable effect, or conversionRate when there are conversions, or clickThroughRate)"),
+			mcp.Enum("clickThroughRate", "conversionRate", "addToCartRate", "purchaseRate"),
+		),
+	)
+
+	mcps.AddTool(analyzeABTestTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		idFloat, ok := req.Params.Arguments["id"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("invalid AB test ID")
+		}
+		confidence := defaultConfidence
+		if v, ok := req.Params.Arguments["confidence"].(float64); ok {
+			if v <= 0.5 || v >= 1 {
+				return nil, fmt.Errorf("confidence must be between 0.5 and 1")
+			}
+			confidence = v
+		}
+		metric, _ := req.Params.Arguments["metric"].(string)
+
+		test, err := getABTest(int(idFloat))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get AB test: %w", err)
+		}
+
+		return mcputil.JSONToolResult(fmt.Sprintf("AB Test %d Analysis", test.ABTestID), analyze(test, confidence, metric, time.Now()))
+	})
+}
+
+// analyze computes the statistics of an A/B test and recommends what to do.
+func analyze(test ABTest, confidence float64, metric string, now time.Time) Analysis {
+	a := Analysis{
+		ABTestID:      test.ABTestID,
+		Name:          test.Name,
+		Status:        test.Status,
+		Confidence:    confidence,
+		PrimaryMetric: primaryMetric(test, metric),
+	}
+	if len(test.Variants) < 2 {
+		a.Recommendation = Recommendation{Action: ActionStop, Reason: "the A/B test has fewer than 2 variants"}
+		return a
+	}
+
+	end := now
+	if test.Status != "active" && test.UpdatedAt.After(test.CreatedAt) {
+		end = test.UpdatedAt
+	}
+	a.DaysElapsed = math.Max(end.Sub(test.CreatedAt).Hours()/24, 0)
+	mde, mdeMetric := minimumDetectableEffect(test)
+
+	control := test.Variants[0]
+	for i, v := range test.Variants {
+		va := VariantAnalysis{
+			Index:             v.Index,
+			Description:       v.Description,
+			TrafficPercentage: v.TrafficPercentage,
+			Users:             v.UserCount,
+			TrackedSearches:   v.TrackedSearchCount,
+		}
+		if i > 0 {
+			va.Metrics = compare(control, v, confidence, a.DaysElapsed, test.Status == "active", mde, mdeMetric)
+		}
+		a.Variants = append(a.Variants, va)
+	}
+	a.SampleRatio = sampleRatio(test.Variants)
+	a.Recommendation = recommend(test, a, now)
+	return a
+}
+
+// compare computes the metrics of a variant against the control.
+func compare(control, v Variant, confidence, days float64, active bool, mde float64, mdeMetric string) []MetricAnalysis {
+	var metrics []MetricAnalysis
+	n1, n2 := control.TrackedSearchCount, v.TrackedSearchCount
+	// daysRemaining estimates when both variants reach the sample size needed,
+	// at the traffic observed so far.
+	daysRemaining := func(needed float64) *float64 {
+		if !active || days <= 0 || math.IsInf(needed, 1) {
+			return nil
+		}
+		rate := float64(min(n1, n2)) / days
+		if rate == 0 {
+			return nil
+		}
+		return num(math.Max(needed-float64(min(n1, n2)), 0) / rate)
+	}
+
+	for _, m := range rateMetrics {
+		x1, x2 := m.count(control), m.count(v)
+		if x1 == 0 && x2 == 0 {
+			continue
+		}
+		ma := MetricAnalysis{Metric: m.name}
+		if n1 > 0 {
+			ma.Control = float64(x1) / float64(n1)
+		}
+		if n2 > 0 {
+			ma.Variant = float64(x2) / float64(n2)
+		}
+		pValue, low, high := proportionTest(x1, n1, x2, n2, confidence)
+		setLift(&ma, low, high, pValue, confidence)
+
+		target := ma.Variant
+		if m.name == mdeMetric {
+			target = ma.Control * (1 + mde)
+		}
+		needed := proportionSampleSize(ma.Control, target, confidence, power)
+		ma.SampleSize = count(needed)
+		ma.DaysRemaining = daysRemaining(needed)
+		metrics = append(metrics, ma)
+	}
+
+	currencies := make([]string, 0, len(control.Currencies))
+	for c := range control.Currencies {
+		if _, ok := v.Currencies[c]; ok {
+			currencies = append(currencies, c)
+		}
+	}
+	sort.Strings(currencies)
+	for _, c := range currencies {
+		c1, c2 := control.Currencies[c], v.Currencies[c]
+		if c1.Revenue == 0 && c2.Revenue == 0 {
+			continue
+		}
+		ma := MetricAnalysis{Metric: "revenue:" + c, Control: c1.Mean, Variant: c2.Mean}
+		pValue, low, high := meanTest(c1.Mean, c1.StandardDeviation, n1, c2.Mean, c2.StandardDeviation, n2, confidence)
+		setLift(&ma, low, high, pValue, confidence)
+		needed := meanSampleSize(c1.Mean, c1.StandardDeviation, c2.Mean, c2.StandardDeviation, confidence, power)
+		ma.SampleSize = count(needed)
+		ma.DaysRemaining = daysRemaining(needed)
+		metrics = append(metrics, ma)
+	}
+	return metrics
+}
+
+// setLift sets the lift of a metric and its confidence interval, from the
+// confidence interval of the difference with the control.
+func setLift(ma *MetricAnalysis, low, high, pValue, confidence float64) {
+	ma.PValue = num(pValue)
+	ma.Significant = ma.PValue != nil && pValue < 1-confidence
+	if ma.Control != 0 {
+		ma.Lift = num((ma.Variant - ma.Control) / ma.Control)
+		ma.LiftLow = num(low / ma.Control)
+		ma.LiftHigh = num(high / ma.Control)
+	}
+}
+
+// sampleRatio tests the observed traffic split against the configured
+// trafficPercentage with a chi-square goodness-of-fit test.
+func sampleRatio(variants []Variant) *SampleRatio {
+	sr := &SampleRatio{}
+	total := 0
+	for _, v := range variants {
+		// Traffic is split by user, searches are only a fallback.
+		n := v.UserCount
+		if n == 0 {
+			n = v.SearchCount
+		}
+		sr.Observed = append(sr.Observed, n)
+		total += n
+	}
+	if total == 0 {
+		return nil
+	}
+	for i, v := range variants {
+		e := float64(total) * float64(v.TrafficPercentage) / 100
+		sr.Expected = append(sr.Expected, e)
+		if e > 0 {
+			d := float64(sr.Observed[i]) - e
+			sr.ChiSquare += d * d / e
+		}
+	}
+	sr.PValue = chiSquarePValue(sr.ChiSquare, len(variants)-1)
+	sr.Mismatch = sr.PValue < srmThreshold
+	return sr
+}
+
+// recommend decides whether to keep running an A/B test, stop it, or
+// declare a winner, from its primary metric.
+func recommend(test ABTest, a Analysis, now time.Time) Recommendation {
+	switch {
+	case test.Status == "failed":
+		return Recommendation{Action: ActionStop, Reason: "the A/B test failed to start"}
+	case a.SampleRatio != nil && a.SampleRatio.Mismatch:
+		return Recommendation{Action: ActionStop, Reason: fmt.Sprintf("sample ratio mismatch: the observed traffic split %v doesn't match the configured percentages (p=%.2g), so the results are unreliable. Check how user tokens are set and the outlier settings", a.SampleRatio.Observed, a.SampleRatio.PValue)}
+	}
+	for _, v := range a.Variants {
+		if v.TrackedSearches < minTrackedSearches {
+			if test.Status != "active" {
+				return Recommendation{Action: ActionStop, Reason: fmt.Sprintf("the A/B test ended with too little data: %s has %d tracked searches. Check that clickAnalytics is enabled", v.Index, v.TrackedSearches)}
+			}
+			return Recommendation{Action: ActionKeepRunning, Reason: fmt.Sprintf("not enough data yet: %s has %d tracked searches", v.Index, v.TrackedSearches)}
+		}
+	}
+
+	// Pick the variant with the best significant lift on the primary metric.
+	var best *MetricAnalysis
+	bestIndex, bestVariant := "", 0
+	var slowest *float64
+	for pos, v := range a.Variants[1:] {
+		for i, m := range v.Metrics {
+			if m.Metric != a.PrimaryMetric {
+				continue
+			}
+			if m.Significant && m.Lift != nil && (best == nil || math.Abs(*m.Lift) > math.Abs(*best.Lift)) {
+				best = &v.Metrics[i]
+				bestIndex, bestVariant = v.Index, pos+1
+			}
+			if m.DaysRemaining != nil && (slowest == nil || *m.DaysRemaining > *slowest) {
+				slowest = m.DaysRemaining
+			}
+		}
+	}
+
+	if best != nil {
+		winner, variant := bestIndex, bestVariant
+		if *best.Lift < 0 {
+			winner, variant = a.Variants[0].Index, 0
+		}
+		return Recommendation{Action: ActionWinner, Winner: winner, WinnerVariant: &variant, Reason: fmt.Sprintf("%s has a significant lift of %.1f%% on %s (p=%.2g)", bestIndex, *best.Lift*100, a.PrimaryMetric, *best.PValue)}
+	}
+	if test.Status != "active" {
+		return Recommendation{Action: ActionStop, Reason: fmt.Sprintf("the A/B test ended without a significant difference on %s, keep the control", a.PrimaryMetric)}
+	}
+	if slowest != nil && *slowest < test.EndAt.Sub(now).Hours()/24 {
+		return Recommendation{Action: ActionKeepRunning, Reason: fmt.Sprintf("no significant difference on %s yet, about %.0f more days are needed at the current traffic", a.PrimaryMetric, math.Ceil(*slowest))}
+	}
+	return Recommendation{Action: ActionStop, Reason: fmt.Sprintf("no significant difference on %s, and the A/B test is unlikely to reach significance before it ends on %s: stop it, or extend it if a smaller effect matters", a.PrimaryMetric, test.EndAt.Format(time.DateOnly))}
+}
+
+// primaryMetric returns the metric the recommendation is based on.
+func primaryMetric(test ABTest, metric string) string {
+	if metric != "" {
+		return metric
+	}
+	if _, m := minimumDetectableEffect(test); m != "" {
+		return m
+	}
+	for _, v := range test.Variants {
+		if v.ConversionCount > 0 {
+			return "conversionRate"
+		}
+	}
+	return "clickThroughRate"
+}
+
+// minimumDetectableEffect returns the minimum detectable effect configured
+// for an A/B test, and its metric.
+func minimumDetectableEffect(test ABTest) (float64, string) {
+	mde, _ := test.Configuration["minimumDetectableEffect"].(map[string]any)
+	size, _ := mde["size"].(float64)
+	metric, _ := mde["metric"].(string)
+	if size <= 0 {
+		return 0, ""
+	}
+	return size, metric
+}
+
+// num returns a pointer to f, or nil when f can't be encoded in JSON.
+func num(f float64) *float64 {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil
+	}
+	return &f
+}
+
+// count returns a sample size rounded up, or nil when it's infinite.
+func count(f float64) *int {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f > math.MaxInt32 {
+		return nil
+	}
+	n := int(math.Ceil(f))
+	return &n
+}
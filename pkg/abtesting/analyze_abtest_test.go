@@ -0,0 +1,109 @@
+package abtesting
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnalyzeRecommendation(t *testing.T) {
+	now := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+	variant := func(index string, users, searches, clicks int) Variant {
+		return Variant{
+			Index:              index,
+			TrafficPercentage:  50,
+			UserCount:          users,
+			SearchCount:        searches,
+			TrackedSearchCount: searches,
+			ClickCount:         clicks,
+		}
+	}
+	tests := []struct {
+		name          string
+		status        string
+		control, test Variant
+		action        string
+		winner        string
+		winnerVariant *int
+		mismatch      bool
+	}{
+		{
+			name:          "significant lift",
+			status:        "active",
+			control:       variant("products", 5000, 1000, 100),
+			test:          variant("products_b", 5000, 1000, 130),
+			action:        ActionWinner,
+			winner:        "products_b",
+			winnerVariant: intPtr(1),
+		},
+		{
+			name:          "significant drop keeps the control",
+			status:        "active",
+			control:       variant("products", 5000, 1000, 130),
+			test:          variant("products_b", 5000, 1000, 100),
+			action:        ActionWinner,
+			winner:        "products",
+			winnerVariant: intPtr(0),
+		},
+		{
+			name:     "sample ratio mismatch",
+			status:   "active",
+			control:  variant("products", 5000, 1000, 100),
+			test:     variant("products_b", 4000, 1000, 130),
+			action:   ActionStop,
+			mismatch: true,
+		},
+		{
+			name:    "not enough data",
+			status:  "active",
+			control: variant("products", 50, 50, 5),
+			test:    variant("products_b", 50, 50, 10),
+			action:  ActionKeepRunning,
+		},
+		{
+			// About 200000 more days are needed, which overflows a
+			// time.Duration.
+			name:    "significance out of reach",
+			status:  "active",
+			control: variant("products", 5000, 91000, 9100),
+			test:    variant("products_b", 5000, 91000, 9103),
+			action:  ActionStop,
+		},
+		{
+			name:    "ended without difference",
+			status:  "stopped",
+			control: variant("products", 5000, 1000, 100),
+			test:    variant("products_b", 5000, 1000, 102),
+			action:  ActionStop,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := ABTest{
+				ABTestID:  1,
+				Status:    tt.status,
+				CreatedAt: now.AddDate(0, 0, -14),
+				UpdatedAt: now.AddDate(0, 0, -1),
+				EndAt:     now.AddDate(0, 0, 14),
+				Variants:  []Variant{tt.control, tt.test},
+			}
+			a := analyze(test, defaultConfidence, "", now)
+			rec := a.Recommendation
+			if rec.Action != tt.action || rec.Winner != tt.winner {
+				t.Fatalf("recommendation = %s %q, want %s %q (%s)", rec.Action, rec.Winner, tt.action, tt.winner, rec.Reason)
+			}
+			switch {
+			case tt.winnerVariant == nil && rec.WinnerVariant != nil:
+				t.Errorf("winnerVariant = %d, want none", *rec.WinnerVariant)
+			case tt.winnerVariant != nil && (rec.WinnerVariant == nil || *rec.WinnerVariant != *tt.winnerVariant):
+				t.Errorf("winnerVariant = %v, want %d", rec.WinnerVariant, *tt.winnerVariant)
+			}
+			if mismatch := a.SampleRatio != nil && a.SampleRatio.Mismatch; mismatch != tt.mismatch {
+				t.Errorf("sample ratio mismatch = %v, want %v", mismatch, tt.mismatch)
+			}
+		})
+	}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
@@ -0,0 +1,281 @@
+package abtesting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Ways of creating the variant of an A/B test.
+const (
+	ModeCopy    = "copy"
+	ModeReplica = "replica"
+	ModeSame    = "same"
+)
+
+// defaultConfiguration is used to estimate the duration of an A/B test when
+// no configuration is given.
+var defaultConfiguration = map[string]any{
+	"minimumDetectableEffect": map[string]any{"size": 0.05, "metric": "clickThroughRate"},
+}
+
+// CreatedFromSettings is the outcome of abtesting_create_from_settings.
+type CreatedFromSettings struct {
+	Mode         string           `json:"mode"`
+	VariantIndex string           `json:"variantIndex,omitempty"`
+	Settings     map[string]any   `json:"settings,omitempty"`
+	TaskIDs      []int64          `json:"taskIDs,omitempty"`
+	Variants     []map[string]any `json:"variants"`
+	Estimate     map[string]any   `json:"estimate,omitempty"`
+	Warnings     []string         `json:"warnings,omitempty"`
+	ABTest       map[string]any   `json:"abTest"`
+}
+
+// RegisterCreateFromSettings registers the create_from_settings tool with the MCP server.
+func RegisterCreateFromSettings(mcps *server.MCPServer) {
+	createFromSettingsTool := mcp.NewTool(
+		"abtesting_create_from_settings",
+		mcp.WithDescription("Create an A/B test of a settings change in one step: creates the variant index as a copy or a replica of the base index, applies the settings patch to it and waits for the tasks (or uses customSearchParameters on the base index), estimates the duration with abtesting_estimate_abtest, then creates the A/B test"),
+		mcp.WithString(
+			"name",
+			mcp.Description("A/B test name"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"endAt",
+			mcp.Description("End date and time of the A/B test, in RFC 3339 format (e.g., 2023-06-17T00:00:00Z)"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"indexName",
+			mcp.Description("Base index, used as the control"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"settings",
+			mcp.Description("Settings patch applied to the variant index, as a JSON object (e.g., {\"customRanking\": [\"desc(popularity)\"]})"),
+		),
+		mcp.WithString(
+			"customSearchParameters",
+			mcp.Description("Search parameters of the variant, as a JSON object, to test on the base index itself instead of a variant index (e.g., {\"typoTolerance\": \"min\"})"),
+		),
+		mcp.WithString(
+			"mode",
+			mcp.Description("How to create the variant index from the base index with a settings patch: copy (records, settings, synonyms and rules, the default) or replica (a standard replica, kept in sync with the records of the base index)"),
+			mcp.Enum(ModeCopy, ModeReplica),
+		),
+		mcp.WithString(
+			"variantIndex",
+			mcp.Description("Name of the variant index to create (default: the base index name followed by _abtest)"),
+		),
+		mcp.WithNumber(
+			"trafficPercentage",
+			mcp.Description("Percentage of the traffic sent to the variant (default: 50)"),
+		),
+		mcp.WithString(
+			"description",
+			mcp.Description("Description of the variant"),
+		),
+		mcp.WithString(
+			"configuration",
+			mcp.Description("A/B test configuration as JSON object, used for the estimate and the A/B test. May include 'minimumDetectableEffect' with 'size' and 'metric' fields (default: 5% on clickThroughRate for the estimate), 'outliers' and 'emptySearch' settings."),
+		),
+	)
+
+	mcps.AddTool(createFromSettingsTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, _ := req.Params.Arguments["name"].(string)
+		endAtStr, _ := req.Params.Arguments["endAt"].(string)
+		base, _ := req.Params.Arguments["indexName"].(string)
+		settingsJSON, _ := req.Params.Arguments["settings"].(string)
+		paramsJSON, _ := req.Params.Arguments["customSearchParameters"].(string)
+		mode, _ := req.Params.Arguments["mode"].(string)
+		variantIndex, _ := req.Params.Arguments["variantIndex"].(string)
+		description, _ := req.Params.Arguments["description"].(string)
+		configJSON, _ := req.Params.Arguments["configuration"].(string)
+
+		if name == "" || base == "" {
+			return nil, fmt.Errorf("name and indexName are required")
+		}
+		endAt, err := time.Parse(time.RFC3339, endAtStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endAt, expected RFC 3339 format: %w", err)
+		}
+		traffic := 50
+		if v, ok := req.Params.Arguments["trafficPercentage"].(float64); ok {
+			if v < 1 || v > 99 || v != float64(int(v)) {
+				return nil, fmt.Errorf("trafficPercentage must be an integer between 1 and 99")
+			}
+			traffic = int(v)
+		}
+
+		var settings, params, config map[string]any
+		if settingsJSON != "" {
+			if err := json.Unmarshal([]byte(settingsJSON), &settings); err != nil {
+				return nil, fmt.Errorf("invalid settings JSON: %w", err)
+			}
+		}
+		if paramsJSON != "" {
+			if err := json.Unmarshal([]byte(paramsJSON), &params); err != nil {
+				return nil, fmt.Errorf("invalid customSearchParameters JSON: %w", err)
+			}
+		}
+		if configJSON != "" {
+			if err := json.Unmarshal([]byte(configJSON), &config); err != nil {
+				return nil, fmt.Errorf("invalid configuration JSON: %w", err)
+			}
+		}
+		switch {
+		case len(settings) > 0 && len(params) > 0:
+			return nil, fmt.Errorf("settings and customSearchParameters can't be used together")
+		case len(settings) > 0:
+			if mode == "" {
+				mode = ModeCopy
+			}
+			if _, ok := settings["replicas"]; ok {
+				return nil, fmt.Errorf("the settings patch can't change replicas")
+			}
+			if variantIndex == "" {
+				variantIndex = base + "_abtest"
+			}
+			if variantIndex == base {
+				return nil, fmt.Errorf("variantIndex must differ from indexName")
+			}
+		case len(params) > 0:
+			mode = ModeSame
+		default:
+			return nil, fmt.Errorf("either settings or customSearchParameters is required")
+		}
+
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		out := CreatedFromSettings{Mode: mode, Settings: settings}
+		control := map[string]any{"index": base, "trafficPercentage": 100 - traffic, "description": "control"}
+		variant := map[string]any{"index": base, "trafficPercentage": traffic}
+		if description != "" {
+			variant["description"] = description
+		}
+
+		if mode == ModeSame {
+			variant["customSearchParameters"] = params
+		} else {
+			out.VariantIndex = variantIndex
+			variant["index"] = variantIndex
+			exists, err := client.InitIndex(variantIndex).Exists()
+			if err != nil {
+				return nil, fmt.Errorf("failed to check variant index: %w", err)
+			}
+			if exists {
+				return nil, fmt.Errorf("variant index %s already exists", variantIndex)
+			}
+
+			mcputil.Progress(ctx, req, 0, 4, fmt.Sprintf("creating %s", variantIndex))
+			taskID, err := createVariantIndex(client, mode, base, variantIndex)
+			if err != nil {
+				return nil, err
+			}
+			out.TaskIDs = append(out.TaskIDs, taskID)
+
+			mcputil.Progress(ctx, req, 1, 4, fmt.Sprintf("applying settings to %s", variantIndex))
+			res, err := searchutil.SetRawSettings(client, variantIndex, settings, false)
+			if err != nil {
+				return nil, fmt.Errorf("variant index %s created but failed to apply settings: %w", variantIndex, err)
+			}
+			if err := client.InitIndex(variantIndex).WaitTask(res.TaskID); err != nil {
+				return nil, fmt.Errorf("failed to wait for settings of %s: %w", variantIndex, err)
+			}
+			out.TaskIDs = append(out.TaskIDs, res.TaskID)
+		}
+		out.Variants = []map[string]any{control, variant}
+
+		// The estimate is only guidance: the A/B test is created without it.
+		mcputil.Progress(ctx, req, 2, 4, "estimating the duration")
+		estimateConfig := config
+		if _, ok := config["minimumDetectableEffect"]; !ok {
+			estimateConfig = map[string]any{}
+			for k, v := range config {
+				estimateConfig[k] = v
+			}
+			for k, v := range defaultConfiguration {
+				estimateConfig[k] = v
+			}
+		}
+		var estimate map[string]any
+		if err := algoliautil.Request(apiHost, "ALGOLIA_API_KEY", http.MethodPost, "/2/abtests/estimate", map[string]any{
+			"configuration": estimateConfig,
+			"variants":      out.Variants,
+		}, &estimate); err != nil {
+			out.Warnings = append(out.Warnings, fmt.Sprintf("failed to estimate the duration: %v", err))
+		} else {
+			out.Estimate = estimate
+			if days, ok := estimate["durationDays"].(float64); ok && time.Now().Add(time.Duration(days*24)*time.Hour).After(endAt) {
+				out.Warnings = append(out.Warnings, fmt.Sprintf("the A/B test needs about %.0f days to reach significance, but ends on %s: consider a later endAt", days, endAt.Format(time.DateOnly)))
+			}
+		}
+
+		mcputil.Progress(ctx, req, 3, 4, "creating the A/B test")
+		body := map[string]any{
+			"name":     name,
+			"endAt":    endAtStr,
+			"variants": out.Variants,
+		}
+		if len(config) > 0 {
+			body["configuration"] = config
+		}
+		if err := algoliautil.Request(apiHost, "ALGOLIA_WRITE_API_KEY", http.MethodPost, "/2/abtests", body, &out.ABTest); err != nil {
+			if out.VariantIndex != "" {
+				return nil, fmt.Errorf("variant index %s created but failed to create the A/B test: %w", out.VariantIndex, err)
+			}
+			return nil, fmt.Errorf("failed to create the A/B test: %w", err)
+		}
+
+		return mcputil.JSONToolResult("AB Test Created", out)
+	})
+}
+
+// createVariantIndex creates the variant index as a copy or a standard
+// replica of the base index, and waits for it to be ready.
+func createVariantIndex(client *search.Client, mode, base, variantIndex string) (int64, error) {
+	if mode == ModeCopy {
+		res, err := client.CopyIndex(base, variantIndex)
+		if err != nil {
+			return 0, fmt.Errorf("failed to copy %s to %s: %w", base, variantIndex, err)
+		}
+		if err := res.Wait(); err != nil {
+			return 0, fmt.Errorf("failed to wait for the copy of %s: %w", base, err)
+		}
+		return res.TaskID, nil
+	}
+
+	// Declaring a replica on the base index creates it, with a copy of the
+	// base index settings.
+	settings, err := searchutil.GetRawSettings(client, base)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get settings of %s: %w", base, err)
+	}
+	replicas := searchutil.GetReplicas(settings)
+	for _, r := range replicas {
+		if searchutil.ReplicaName(r) == variantIndex {
+			return 0, fmt.Errorf("%s is already a replica of %s", variantIndex, base)
+		}
+	}
+	res, err := searchutil.SetRawSettings(client, base, map[string]any{"replicas": append(replicas, variantIndex)}, false)
+	if err != nil {
+		return 0, fmt.Errorf("failed to add replica %s to %s: %w", variantIndex, base, err)
+	}
+	if err := client.InitIndex(base).WaitTask(res.TaskID); err != nil {
+		return 0, fmt.Errorf("failed to wait for replica creation: %w", err)
+	}
+	return res.TaskID, nil
+}
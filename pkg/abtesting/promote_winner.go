@@ -0,0 +1,258 @@
+package abtesting
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// queryOnlyParameters are search parameters that can be used as
+// customSearchParameters but aren't index settings.
+var queryOnlyParameters = []string{
+	"query", "similarQuery", "filters", "facetFilters", "optionalFilters", "numericFilters", "tagFilters",
+	"sumOrFiltersScores", "restrictSearchableAttributes", "facets", "facetingAfterDistinct", "page",
+	"offset", "length", "aroundLatLng", "aroundLatLngViaIP", "aroundRadius", "aroundPrecision",
+	"minimumAroundRadius", "insideBoundingBox", "insidePolygon", "naturalLanguages", "ruleContexts",
+	"personalizationImpact", "userToken", "getRankingInfo", "explain", "synonyms", "clickAnalytics",
+	"analytics", "analyticsTags", "percentileComputation", "enableABTest",
+}
+
+// Promotion is the outcome of abtesting_promote_winner.
+type Promotion struct {
+	ABTestID     int                        `json:"abTestID"`
+	Status       string                     `json:"status"`
+	Winner       int                        `json:"winner"`
+	WinnerIndex  string                     `json:"winnerIndex"`
+	Reason       string                     `json:"reason"`
+	ControlIndex string                     `json:"controlIndex"`
+	VariantIndex string                     `json:"variantIndex,omitempty"`
+	DryRun       bool                       `json:"dryRun"`
+	Settings     []searchutil.SettingChange `json:"settings"`
+	Skipped      []string                   `json:"skipped,omitempty"`
+	DeletedIndex string                     `json:"deletedIndex,omitempty"`
+	TaskIDs      []int64                    `json:"taskIDs,omitempty"`
+	Warnings     []string                   `json:"warnings,omitempty"`
+}
+
+// RegisterPromoteWinner registers the promote_winner tool with the MCP server.
+func RegisterPromoteWinner(mcps *server.MCPServer) {
+	promoteWinnerTool := mcp.NewTool(
+		"abtesting_promote_winner",
+		mcp.WithDescription("Apply the winner of a finished A/B test to the control index: determines the winning variant with abtesting_analyze (or uses the given one), copies its settings, or its customSearchParameters merged into the control settings, onto the control index, and optionally deletes the variant index. Use dryRun to preview the settings diff."),
+		mcp.WithNumber(
+			"id",
+			mcp.Description("Unique A/B test identifier"),
+			mcp.Required(),
+		),
+		mcp.WithNumber(
+			"variant",
+			mcp.Description("Position of the variant to promote (0 for the control, 1 for the variant), instead of the winner found by the analysis"),
+		),
+		mcp.WithNumber(
+			"confidence",
+			mcp.Description("Confidence level used to find the winner (default: 0.95)"),
+		),
+		mcp.WithString(
+			"metric",
+			mcp.Description("Metric used to find the winner (defaults to the primary metric of abtesting_analyze)"),
+			mcp.Enum("clickThroughRate", "conversionRate", "addToCartRate", "purchaseRate"),
+		),
+		mcp.WithString(
+			"keys",
+			mcp.Description("Comma-separated list of settings to promote. Defaults to every differing setting except replicas, primary and version"),
+		),
+		mcp.WithBoolean(
+			"deleteVariantIndex",
+			mcp.Description("Delete the variant index once the settings are promoted, detaching it first if it's a replica of the control index"),
+		),
+		mcp.WithBoolean(
+			"forwardToReplicas",
+			mcp.Description("Whether to forward the settings to the replicas of the control index"),
+		),
+		mcp.WithBoolean(
+			"dryRun",
+			mcp.Description("Only return the settings diff and the index that would be deleted"),
+		),
+	)
+
+	mcps.AddTool(promoteWinnerTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		idFloat, ok := req.Params.Arguments["id"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("invalid AB test ID")
+		}
+		confidence := defaultConfidence
+		if v, ok := req.Params.Arguments["confidence"].(float64); ok {
+			if v <= 0.5 || v >= 1 {
+				return nil, fmt.Errorf("confidence must be between 0.5 and 1")
+			}
+			confidence = v
+		}
+		metric, _ := req.Params.Arguments["metric"].(string)
+		keysStr, _ := req.Params.Arguments["keys"].(string)
+		deleteVariant, _ := req.Params.Arguments["deleteVariantIndex"].(bool)
+		forwardToReplicas, _ := req.Params.Arguments["forwardToReplicas"].(bool)
+		dryRun, _ := req.Params.Arguments["dryRun"].(bool)
+
+		test, err := getABTest(int(idFloat))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get AB test: %w", err)
+		}
+		if test.Status != "stopped" && test.Status != "expired" {
+			return nil, fmt.Errorf("AB test %d is %s: only stopped or expired A/B tests can be promoted, stop it first with abtesting_stop_abtest", test.ABTestID, test.Status)
+		}
+		if len(test.Variants) != 2 {
+			return nil, fmt.Errorf("AB test %d has %d variants, expected 2", test.ABTestID, len(test.Variants))
+		}
+
+		p := Promotion{ABTestID: test.ABTestID, Status: test.Status, DryRun: dryRun, Settings: []searchutil.SettingChange{}}
+		if v, ok := req.Params.Arguments["variant"].(float64); ok {
+			if v != 0 && v != 1 {
+				return nil, fmt.Errorf("variant must be 0 (control) or 1")
+			}
+			p.Winner = int(v)
+			p.Reason = "chosen explicitly"
+		} else {
+			rec := analyze(test, confidence, metric, time.Now()).Recommendation
+			if rec.Action != ActionWinner || rec.WinnerVariant == nil {
+				return nil, fmt.Errorf("AB test %d has no winner (%s): pass the variant to promote explicitly", test.ABTestID, rec.Reason)
+			}
+			p.Winner = *rec.WinnerVariant
+			p.Reason = rec.Reason
+		}
+
+		control, variant := test.Variants[0], test.Variants[1]
+		winner := test.Variants[p.Winner]
+		p.ControlIndex = control.Index
+		p.WinnerIndex = winner.Index
+		if variant.Index != control.Index {
+			p.VariantIndex = variant.Index
+		}
+		if deleteVariant && p.VariantIndex == "" {
+			p.Warnings = append(p.Warnings, "both variants use the control index, there is no variant index to delete")
+		}
+
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+		current, err := searchutil.GetRawSettings(client, control.Index)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get settings of %s: %w", control.Index, err)
+		}
+
+		settings := map[string]any{}
+		if p.Winner == 0 {
+			p.Warnings = append(p.Warnings, "the control won, its settings are kept")
+		} else {
+			proposed := current
+			if winner.Index != control.Index {
+				if proposed, err = searchutil.GetRawSettings(client, winner.Index); err != nil {
+					return nil, fmt.Errorf("failed to get settings of %s: %w", winner.Index, err)
+				}
+				p.Warnings = append(p.Warnings, fmt.Sprintf("only settings are promoted: rules and synonyms that differ on %s must be promoted separately, e.g. with promote_settings", winner.Index))
+			}
+			proposed, p.Skipped = mergeParameters(proposed, winner.CustomSearchParameters)
+
+			keys := mcputil.SplitList(keysStr)
+			// The winner is the source of the promotion, the control its target.
+			for _, c := range searchutil.DiffSettings(proposed, current) {
+				if slices.Contains(searchutil.UnpromotedSettings, c.Key) || (len(keys) > 0 && !slices.Contains(keys, c.Key)) {
+					p.Skipped = append(p.Skipped, c.Key)
+					continue
+				}
+				p.Settings = append(p.Settings, c)
+				// A nil value resets a setting only defined on the control.
+				settings[c.Key] = c.Source
+			}
+		}
+
+		if dryRun {
+			if deleteVariant {
+				p.DeletedIndex = p.VariantIndex
+			}
+			return mcputil.JSONToolResult("AB Test Promotion Preview", p)
+		}
+
+		if len(settings) > 0 {
+			res, err := searchutil.SetRawSettings(client, control.Index, settings, forwardToReplicas)
+			if err != nil {
+				return nil, fmt.Errorf("failed to set settings of %s: %w", control.Index, err)
+			}
+			if err := client.InitIndex(control.Index).WaitTask(res.TaskID); err != nil {
+				return nil, fmt.Errorf("failed to wait for settings of %s: %w", control.Index, err)
+			}
+			p.TaskIDs = append(p.TaskIDs, res.TaskID)
+		}
+
+		if deleteVariant && p.VariantIndex != "" {
+			taskIDs, err := deleteVariantIndex(client, control.Index, p.VariantIndex, current)
+			p.TaskIDs = append(p.TaskIDs, taskIDs...)
+			if err != nil {
+				return nil, fmt.Errorf("settings promoted but failed to delete %s: %w", p.VariantIndex, err)
+			}
+			p.DeletedIndex = p.VariantIndex
+		}
+
+		return mcputil.JSONToolResult("AB Test Promotion", p)
+	})
+}
+
+// mergeParameters returns the settings with the customSearchParameters of a
+// variant applied, and the parameters that aren't settings.
+func mergeParameters(settings, params map[string]any) (map[string]any, []string) {
+	merged := make(map[string]any, len(settings)+len(params))
+	for k, v := range settings {
+		merged[k] = v
+	}
+	var skipped []string
+	for k, v := range params {
+		if slices.Contains(queryOnlyParameters, k) {
+			skipped = append(skipped, k)
+			continue
+		}
+		merged[k] = v
+	}
+	sort.Strings(skipped)
+	return merged, skipped
+}
+
+// deleteVariantIndex deletes the variant index of an A/B test, after
+// detaching it from the control index when it's one of its replicas.
+func deleteVariantIndex(client *search.Client, controlIndex, variantIndex string, controlSettings map[string]any) ([]int64, error) {
+	var taskIDs []int64
+	replicas := []string{}
+	found := false
+	for _, r := range searchutil.GetReplicas(controlSettings) {
+		if searchutil.ReplicaName(r) == variantIndex {
+			found = true
+			continue
+		}
+		replicas = append(replicas, r)
+	}
+	if found {
+		// A replica can only be deleted once it's detached from its primary.
+		res, err := searchutil.SetRawSettings(client, controlIndex, map[string]any{"replicas": replicas}, false)
+		if err != nil {
+			return taskIDs, fmt.Errorf("failed to detach replica: %w", err)
+		}
+		taskIDs = append(taskIDs, res.TaskID)
+		if err := client.InitIndex(controlIndex).WaitTask(res.TaskID); err != nil {
+			return taskIDs, fmt.Errorf("failed to wait for replica detachment: %w", err)
+		}
+	}
+
+	res, err := client.InitIndex(variantIndex).Delete()
+	if err != nil {
+		return taskIDs, err
+	}
+	return append(taskIDs, res.TaskID), nil
+}
@@ -0,0 +1,130 @@
+package abtesting
+
+import "math"
+
+// normalCDF returns the probability that a standard normal variable is below z.
+func normalCDF(z float64) float64 {
+	return 0.5 * math.Erfc(-z/math.Sqrt2)
+}
+
+// normalQuantile returns z such that normalCDF(z) == p.
+func normalQuantile(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+// twoSidedPValue returns the two-sided p-value of a z statistic.
+func twoSidedPValue(z float64) float64 {
+	return math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+// proportionTest compares the rates x1/n1 (control) and x2/n2 (variant) with
+// a two-proportion z-test. It returns the p-value and the confidence interval
+// of the difference of the rates, at the given confidence level.
+func proportionTest(x1, n1, x2, n2 int, confidence float64) (pValue, low, high float64) {
+	if n1 == 0 || n2 == 0 {
+		return math.NaN(), math.NaN(), math.NaN()
+	}
+	p1 := clamp01(float64(x1) / float64(n1))
+	p2 := clamp01(float64(x2) / float64(n2))
+	pooled := clamp01(float64(x1+x2) / float64(n1+n2))
+
+	pValue = 1
+	if se := math.Sqrt(pooled * (1 - pooled) * (1/float64(n1) + 1/float64(n2))); se > 0 {
+		pValue = twoSidedPValue((p2 - p1) / se)
+	}
+	se := math.Sqrt(p1*(1-p1)/float64(n1) + p2*(1-p2)/float64(n2))
+	z := normalQuantile(1 - (1-confidence)/2)
+	return pValue, p2 - p1 - z*se, p2 - p1 + z*se
+}
+
+// meanTest compares two means from their standard deviations and sample
+// sizes with Welch's test, approximated by a z-test for large samples. It
+// returns the p-value and the confidence interval of the difference.
+func meanTest(m1, sd1 float64, n1 int, m2, sd2 float64, n2 int, confidence float64) (pValue, low, high float64) {
+	if n1 == 0 || n2 == 0 {
+		return math.NaN(), math.NaN(), math.NaN()
+	}
+	se := math.Sqrt(sd1*sd1/float64(n1) + sd2*sd2/float64(n2))
+	pValue = 1
+	if se > 0 {
+		pValue = twoSidedPValue((m2 - m1) / se)
+	}
+	z := normalQuantile(1 - (1-confidence)/2)
+	return pValue, m2 - m1 - z*se, m2 - m1 + z*se
+}
+
+// proportionSampleSize returns the number of samples per variant needed to
+// detect a change of a rate from p1 to p2, at the given confidence level and
+// power.
+func proportionSampleSize(p1, p2, confidence, power float64) float64 {
+	if p1 == p2 {
+		return math.Inf(1)
+	}
+	p1, p2 = clamp01(p1), clamp01(p2)
+	z := normalQuantile(1-(1-confidence)/2) + normalQuantile(power)
+	return z * z * (p1*(1-p1) + p2*(1-p2)) / ((p2 - p1) * (p2 - p1))
+}
+
+// meanSampleSize is like proportionSampleSize, for means.
+func meanSampleSize(m1, sd1, m2, sd2, confidence, power float64) float64 {
+	if m1 == m2 {
+		return math.Inf(1)
+	}
+	z := normalQuantile(1-(1-confidence)/2) + normalQuantile(power)
+	return z * z * (sd1*sd1 + sd2*sd2) / ((m2 - m1) * (m2 - m1))
+}
+
+// chiSquarePValue returns the p-value of a chi-square statistic with df
+// degrees of freedom, from the regularized upper incomplete gamma function.
+func chiSquarePValue(chi2 float64, df int) float64 {
+	if chi2 <= 0 {
+		return 1
+	}
+	return upperGamma(float64(df)/2, chi2/2)
+}
+
+// upperGamma returns the regularized upper incomplete gamma function Q(s, x),
+// with a series for small x and a continued fraction otherwise.
+func upperGamma(s, x float64) float64 {
+	lgamma, _ := math.Lgamma(s)
+	if x < s+1 {
+		sum, term := 1/s, 1/s
+		for n := 1; n < 1000; n++ {
+			term *= x / (s + float64(n))
+			sum += term
+			if term < sum*1e-15 {
+				break
+			}
+		}
+		return 1 - sum*math.Exp(-x+s*math.Log(x)-lgamma)
+	}
+	// Lentz's algorithm
+	const tiny = 1e-300
+	b := x + 1 - s
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i < 1000; i++ {
+		an := -float64(i) * (float64(i) - s)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return math.Exp(-x+s*math.Log(x)-lgamma) * h
+}
+
+func clamp01(p float64) float64 {
+	return math.Max(0, math.Min(1, p))
+}
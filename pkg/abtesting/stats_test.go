@@ -0,0 +1,129 @@
+package abtesting
+
+import (
+	"math"
+	"testing"
+)
+
+func near(got, want, tol float64) bool {
+	return math.Abs(got-want) <= tol
+}
+
+func TestNormalQuantile(t *testing.T) {
+	tests := []struct {
+		p, want float64
+	}{
+		{0.5, 0},
+		{0.8, 0.8416212},
+		{0.975, 1.9599640},
+		{0.025, -1.9599640},
+	}
+	for _, tt := range tests {
+		got := normalQuantile(tt.p)
+		if !near(got, tt.want, 1e-6) {
+			t.Errorf("normalQuantile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+		if cdf := normalCDF(got); !near(cdf, tt.p, 1e-9) {
+			t.Errorf("normalCDF(normalQuantile(%v)) = %v", tt.p, cdf)
+		}
+	}
+}
+
+func TestUpperGamma(t *testing.T) {
+	tests := []struct {
+		name  string
+		s, x  float64
+		want  float64
+		lentz bool
+	}{
+		// Q(1, x) = exp(-x) and Q(1/2, x) = erfc(sqrt(x)).
+		{"series s=1", 1, 0.5, math.Exp(-0.5), false},
+		{"lentz s=1", 1, 3, math.Exp(-3), true},
+		{"series s=0.5", 0.5, 1, math.Erfc(1), false},
+		{"lentz s=0.5", 0.5, 4, math.Erfc(2), true},
+		{"lentz large x", 2.5, 40, 8.3918251e-16, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if lentz := tt.x >= tt.s+1; lentz != tt.lentz {
+				t.Fatalf("case doesn't exercise the expected branch")
+			}
+			got := upperGamma(tt.s, tt.x)
+			if !near(got, tt.want, tt.want*1e-6) {
+				t.Errorf("upperGamma(%v, %v) = %v, want %v", tt.s, tt.x, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChiSquarePValue(t *testing.T) {
+	tests := []struct {
+		chi2 float64
+		df   int
+		want float64
+	}{
+		{3.841, 1, 0.0500137},
+		{1, 1, 0.3173105},
+		{2, 2, math.Exp(-1)},
+		{10, 2, math.Exp(-5)},
+		{0, 1, 1},
+		{-1, 3, 1},
+	}
+	for _, tt := range tests {
+		got := chiSquarePValue(tt.chi2, tt.df)
+		if !near(got, tt.want, 1e-6) {
+			t.Errorf("chiSquarePValue(%v, %d) = %v, want %v", tt.chi2, tt.df, got, tt.want)
+		}
+	}
+}
+
+func TestProportionTest(t *testing.T) {
+	tests := []struct {
+		name              string
+		x1, n1, x2, n2    int
+		pValue, low, high float64
+		nan               bool
+	}{
+		{name: "lift", x1: 100, n1: 1000, x2: 130, n2: 1000, pValue: 0.0354885, low: 0.0020679, high: 0.0579321},
+		{name: "drop", x1: 130, n1: 1000, x2: 100, n2: 1000, pValue: 0.0354885, low: -0.0579321, high: -0.0020679},
+		{name: "no events", x1: 0, n1: 1000, x2: 0, n2: 1000, pValue: 1, low: 0, high: 0},
+		{name: "empty variant", x1: 10, n1: 100, x2: 0, n2: 0, nan: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pValue, low, high := proportionTest(tt.x1, tt.n1, tt.x2, tt.n2, 0.95)
+			if tt.nan {
+				if !math.IsNaN(pValue) || !math.IsNaN(low) || !math.IsNaN(high) {
+					t.Errorf("proportionTest = %v, %v, %v, want NaN", pValue, low, high)
+				}
+				return
+			}
+			if !near(pValue, tt.pValue, 1e-6) || !near(low, tt.low, 1e-6) || !near(high, tt.high, 1e-6) {
+				t.Errorf("proportionTest = %v, [%v, %v], want %v, [%v, %v]", pValue, low, high, tt.pValue, tt.low, tt.high)
+			}
+		})
+	}
+}
+
+func TestProportionSampleSize(t *testing.T) {
+	tests := []struct {
+		p1, p2 float64
+		want   float64
+	}{
+		{0.1, 0.12, 3838.1022},
+		{0.12, 0.1, 3838.1022},
+		{0.1, 0.1, math.Inf(1)},
+	}
+	for _, tt := range tests {
+		got := proportionSampleSize(tt.p1, tt.p2, 0.95, 0.8)
+		if math.IsInf(tt.want, 1) {
+			if !math.IsInf(got, 1) {
+				t.Errorf("proportionSampleSize(%v, %v) = %v, want +Inf", tt.p1, tt.p2, got)
+			}
+			continue
+		}
+		if !near(got, tt.want, 1e-3) {
+			t.Errorf("proportionSampleSize(%v, %v) = %v, want %v", tt.p1, tt.p2, got, tt.want)
+		}
+	}
+}
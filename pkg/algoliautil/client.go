@@ -0,0 +1,19 @@
+package algoliautil
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+)
+
+// NewClient returns a search client authenticated with the API key read from
+// the given environment variable.
+func NewClient(apiKeyEnv string) (*search.Client, error) {
+	appID := os.Getenv("ALGOLIA_APP_ID")
+	apiKey := os.Getenv(apiKeyEnv)
+	if appID == "" || apiKey == "" {
+		return nil, fmt.Errorf("ALGOLIA_APP_ID and %s environment variables are required", apiKeyEnv)
+	}
+	return search.NewClient(appID, apiKey), nil
+}
@@ -0,0 +1,82 @@
+// Package algoliautil holds the helpers shared by the tools calling Algolia
+// APIs with the credentials read from the environment.
+package algoliautil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+)
+
+// Request sends a request to an Algolia API host, authenticated with the API
+// key read from the given environment variable, and decodes the JSON
+// response into v when v is not nil and there is a response body.
+func Request(host, apiKeyEnv, method, path string, body, v any) error {
+	appID := os.Getenv("ALGOLIA_APP_ID")
+	apiKey := os.Getenv(apiKeyEnv)
+	if appID == "" || apiKey == "" {
+		return fmt.Errorf("ALGOLIA_APP_ID and %s environment variables are required", apiKeyEnv)
+	}
+
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		r = bytes.NewReader(b)
+	}
+
+	// Create HTTP client and request
+	client := &http.Client{}
+	httpReq, err := http.NewRequest(method, host+path, r)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set headers
+	httpReq.Header.Set("x-algolia-application-id", appID)
+	httpReq.Header.Set("x-algolia-api-key", apiKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	// Execute request
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Check for error response
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var errResp map[string]any
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+			return fmt.Errorf("Algolia API error (status %d)", resp.StatusCode)
+		}
+		return fmt.Errorf("Algolia API error: %v", errResp)
+	}
+
+	// Parse response
+	if v == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// CustomRequest sends a request with a search client, for the APIs served
+// by the search hosts, and decodes the JSON response.
+func CustomRequest(client *search.Client, k call.Kind, method, path string, body any) (map[string]any, error) {
+	var res map[string]any
+	if err := client.CustomRequest(&res, method, path, body, k); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
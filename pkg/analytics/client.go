@@ -0,0 +1,32 @@
+package analytics
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/algolia/mcp/pkg/algoliautil"
+)
+
+// apiHost is the host of the Analytics API.
+const apiHost = "https://analytics.algolia.com"
+
+// get sends a GET request to the Analytics API and decodes the response into v.
+func get(path string, q url.Values, v any) error {
+	return algoliautil.Request(apiHost, "ALGOLIA_API_KEY", http.MethodGet, path+"?"+q.Encode(), nil, v)
+}
+
+// periodParams returns the query parameters selecting an index and a period.
+func periodParams(index, startDate, endDate, tags string) url.Values {
+	q := url.Values{}
+	q.Add("index", index)
+	if startDate != "" {
+		q.Add("startDate", startDate)
+	}
+	if endDate != "" {
+		q.Add("endDate", endDate)
+	}
+	if tags != "" {
+		q.Add("tags", tags)
+	}
+	return q
+}
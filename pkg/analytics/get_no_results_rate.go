@@ -2,16 +2,37 @@ package analytics
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
-	"os"
 
 	"github.com/algolia/mcp/pkg/mcputil"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// NoResultsRate is the fraction of searches without results over a period.
+type NoResultsRate struct {
+	Rate          float64             `json:"rate"`
+	Count         int                 `json:"count"`
+	NoResultCount int                 `json:"noResultCount"`
+	Dates         []NoResultsRateDate `json:"dates"`
+}
+
+// NoResultsRateDate is the fraction of searches without results on a day.
+type NoResultsRateDate struct {
+	Date          string  `json:"date"`
+	Rate          float64 `json:"rate"`
+	Count         int     `json:"count"`
+	NoResultCount int     `json:"noResultCount"`
+}
+
+// GetNoResultsRate returns the no-results rate of an index, with a daily
+// breakdown. Empty dates default to the last 8 days, as in the Analytics API.
+func GetNoResultsRate(index, startDate, endDate, tags string) (NoResultsRate, error) {
+	var result NoResultsRate
+	err := get("/2/searches/noResultRate", periodParams(index, startDate, endDate, tags), &result)
+	return result, err
+}
+
 // RegisterGetNoResultsRate registers the get_no_results_rate tool with the MCP server.
 func RegisterGetNoResultsRate(mcps *server.MCPServer) {
 	getNoResultsRateTool := mcp.NewTool(
@@ -37,69 +58,18 @@ func RegisterGetNoResultsRate(mcps *server.MCPServer) {
 	)
 
 	mcps.AddTool(getNoResultsRateTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		appID := os.Getenv("ALGOLIA_APP_ID")
-		apiKey := os.Getenv("ALGOLIA_API_KEY")
-		if appID == "" || apiKey == "" {
-			return nil, fmt.Errorf("ALGOLIA_APP_ID and ALGOLIA_API_KEY environment variables are required")
-		}
-
 		// Extract parameters
 		index, _ := req.Params.Arguments["index"].(string)
 		if index == "" {
 			return nil, fmt.Errorf("index parameter is required")
 		}
+		startDate, _ := req.Params.Arguments["startDate"].(string)
+		endDate, _ := req.Params.Arguments["endDate"].(string)
+		tags, _ := req.Params.Arguments["tags"].(string)
 
-		// Create HTTP client and request
-		client := &http.Client{}
-		url := "https://analytics.algolia.com/2/searches/noResultRate"
-		httpReq, err := http.NewRequest(http.MethodGet, url, nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", err)
-		}
-
-		// Set headers
-		httpReq.Header.Set("x-algolia-application-id", appID)
-		httpReq.Header.Set("x-algolia-api-key", apiKey)
-		httpReq.Header.Set("Content-Type", "application/json")
-
-		// Add query parameters
-		q := httpReq.URL.Query()
-		q.Add("index", index)
-
-		if startDate, ok := req.Params.Arguments["startDate"].(string); ok && startDate != "" {
-			q.Add("startDate", startDate)
-		}
-
-		if endDate, ok := req.Params.Arguments["endDate"].(string); ok && endDate != "" {
-			q.Add("endDate", endDate)
-		}
-
-		if tags, ok := req.Params.Arguments["tags"].(string); ok && tags != "" {
-			q.Add("tags", tags)
-		}
-
-		httpReq.URL.RawQuery = q.Encode()
-
-		// Execute request
-		resp, err := client.Do(httpReq)
+		result, err := GetNoResultsRate(index, startDate, endDate, tags)
 		if err != nil {
-			return nil, fmt.Errorf("failed to execute request: %w", err)
-		}
-		defer resp.Body.Close()
-
-		// Check for error response
-		if resp.StatusCode != http.StatusOK {
-			var errResp map[string]any
-			if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
-				return nil, fmt.Errorf("Algolia API error (status %d)", resp.StatusCode)
-			}
-			return nil, fmt.Errorf("Algolia API error: %v", errResp)
-		}
-
-		// Parse response
-		var result map[string]any
-		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-			return nil, fmt.Errorf("failed to parse response: %w", err)
+			return nil, err
 		}
 
 		return mcputil.JSONToolResult("No Results Rate", result)
@@ -0,0 +1,26 @@
+package analytics
+
+import "strconv"
+
+// NoResultSearch is a search that returned no results, with how often it was made.
+type NoResultSearch struct {
+	Search          string `json:"search"`
+	Count           int    `json:"count"`
+	WithFilterCount int    `json:"withFilterCount"`
+}
+
+// GetNoResultSearches returns the most frequent searches without results on
+// an index. Empty dates default to the last 8 days, as in the Analytics API.
+func GetNoResultSearches(index, startDate, endDate string, limit int) ([]NoResultSearch, error) {
+	q := periodParams(index, startDate, endDate, "")
+	if limit > 0 {
+		q.Add("limit", strconv.Itoa(limit))
+	}
+	var result struct {
+		Searches []NoResultSearch `json:"searches"`
+	}
+	if err := get("/2/searches/noResults", q, &result); err != nil {
+		return nil, err
+	}
+	return result.Searches, nil
+}
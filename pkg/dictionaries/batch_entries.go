@@ -0,0 +1,199 @@
+package dictionaries
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// entryChange is the effect of a batch on a single dictionary entry.
+type entryChange struct {
+	ObjectID string         `json:"objectID"`
+	Change   string         `json:"change"`
+	Before   map[string]any `json:"before,omitempty"`
+	After    map[string]any `json:"after,omitempty"`
+}
+
+// RegisterBatchEntries registers the batch_entries tool with the MCP server.
+func RegisterBatchEntries(mcps *server.MCPServer) {
+	batchEntriesTool := mcp.NewTool(
+		"dictionaries_batch_entries",
+		mcp.WithDescription("Add, update or delete custom dictionary entries. Returns a diff preview against the current entries, and only applies it when apply is true"),
+		withDictionaryName(),
+		mcp.WithString(
+			"entries",
+			mcp.Description("Entries to add or update as a JSON array. Each entry needs an objectID and a language, and: for stopwords a 'word' (and optional 'state': enabled or disabled), for plurals a 'words' array of at least 2 words, for compounds a 'word' and a 'decomposition' array. Example: [{\"objectID\":\"de-kinder\",\"language\":\"de\",\"words\":[\"Kind\",\"Kinder\"]}]"),
+		),
+		mcp.WithString(
+			"deleteObjectIDs",
+			mcp.Description("Comma-separated objectIDs of custom entries to delete"),
+		),
+		mcp.WithBoolean(
+			"apply",
+			mcp.Description("Apply the changes. When false or omitted, only the diff preview is returned"),
+		),
+	)
+
+	mcps.AddTool(batchEntriesTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, err := dictionaryName(req.Params.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		apply, _ := req.Params.Arguments["apply"].(bool)
+
+		var entries []map[string]any
+		if entriesJSON, ok := req.Params.Arguments["entries"].(string); ok && entriesJSON != "" {
+			if err := json.Unmarshal([]byte(entriesJSON), &entries); err != nil {
+				return nil, fmt.Errorf("invalid entries JSON: %w", err)
+			}
+		}
+		for i, e := range entries {
+			if err := validateEntry(name, e); err != nil {
+				return nil, fmt.Errorf("entry at index %d: %w", i, err)
+			}
+		}
+
+		ids, _ := req.Params.Arguments["deleteObjectIDs"].(string)
+		deletes := mcputil.SplitList(ids)
+		if len(entries) == 0 && len(deletes) == 0 {
+			return nil, fmt.Errorf("entries or deleteObjectIDs parameter is required")
+		}
+
+		readClient, err := algoliautil.NewClient("ALGOLIA_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		// Compute the diff against the current entries
+		changes := make([]entryChange, 0, len(entries)+len(deletes))
+		summary := map[string]int{}
+		var requests []map[string]any
+		for _, e := range entries {
+			id, _ := e["objectID"].(string)
+			before, err := findEntry(readClient, name, e)
+			if err != nil {
+				return nil, fmt.Errorf("failed to look up entry %s: %w", id, err)
+			}
+
+			c := entryChange{ObjectID: id, Before: before, After: e}
+			switch {
+			case before == nil:
+				c.Change = "added"
+			case sameEntry(before, e):
+				c.Change = "unchanged"
+			default:
+				c.Change = "changed"
+			}
+			if c.Change != "unchanged" {
+				requests = append(requests, map[string]any{"action": "addEntry", "body": e})
+			}
+			summary[c.Change]++
+			changes = append(changes, c)
+		}
+		for _, id := range deletes {
+			changes = append(changes, entryChange{ObjectID: id, Change: "deleted"})
+			requests = append(requests, map[string]any{"action": "deleteEntry", "body": map[string]any{"objectID": id}})
+			summary["deleted"]++
+		}
+
+		result := map[string]any{
+			"dictionaryName": name,
+			"applied":        false,
+			"summary":        summary,
+			"changes":        changes,
+		}
+		if !apply || len(requests) == 0 {
+			return mcputil.JSONToolResult("Dictionary Batch Preview", result)
+		}
+
+		writeClient, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+		body := map[string]any{
+			"clearExistingDictionaryEntries": false,
+			"requests":                       requests,
+		}
+		res, err := algoliautil.CustomRequest(writeClient, call.Write, http.MethodPost, fmt.Sprintf("/1/dictionaries/%s/batch", name), body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to batch dictionary entries: %w", err)
+		}
+		result["applied"] = true
+		result["taskID"] = res["taskID"]
+
+		return mcputil.JSONToolResult("Dictionary Batch Applied", result)
+	})
+}
+
+func validateEntry(dictionary string, e map[string]any) error {
+	if id, _ := e["objectID"].(string); id == "" {
+		return fmt.Errorf("objectID is required")
+	}
+	if lang, _ := e["language"].(string); lang == "" {
+		return fmt.Errorf("language is required")
+	}
+	switch dictionary {
+	case "stopwords":
+		if word, _ := e["word"].(string); word == "" {
+			return fmt.Errorf("stopwords entries need a word")
+		}
+	case "plurals":
+		if words, _ := e["words"].([]any); len(words) < 2 {
+			return fmt.Errorf("plurals entries need at least 2 words")
+		}
+	case "compounds":
+		word, _ := e["word"].(string)
+		decomposition, _ := e["decomposition"].([]any)
+		if word == "" || len(decomposition) == 0 {
+			return fmt.Errorf("compounds entries need a word and a decomposition")
+		}
+	}
+	return nil
+}
+
+// findEntry looks up the current version of a custom entry, by searching
+// the dictionary for its word and matching its objectID.
+func findEntry(client *search.Client, dictionary string, e map[string]any) (map[string]any, error) {
+	query, _ := e["word"].(string)
+	if words, ok := e["words"].([]any); ok && len(words) > 0 {
+		query, _ = words[0].(string)
+	}
+	body := map[string]any{
+		"query":       query,
+		"language":    e["language"],
+		"hitsPerPage": 1000,
+	}
+	res, err := algoliautil.CustomRequest(client, call.Read, http.MethodPost, fmt.Sprintf("/1/dictionaries/%s/search", dictionary), body)
+	if err != nil {
+		return nil, err
+	}
+
+	hits, _ := res["hits"].([]any)
+	for _, h := range hits {
+		hit, ok := h.(map[string]any)
+		if ok && hit["objectID"] == e["objectID"] {
+			return hit, nil
+		}
+	}
+	return nil, nil
+}
+
+// sameEntry reports whether an existing entry already has all the fields
+// of the new one.
+func sameEntry(before, after map[string]any) bool {
+	for k, v := range after {
+		if !reflect.DeepEqual(before[k], v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,41 @@
+package dictionaries
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterAll registers all Dictionaries tools with the MCP server.
+func RegisterAll(mcps *server.MCPServer) {
+	// Register all Dictionaries tools.
+	RegisterSearchEntries(mcps)
+	RegisterGetSettings(mcps)
+	RegisterSetSettings(mcps)
+	RegisterGetLanguages(mcps)
+	RegisterBatchEntries(mcps)
+}
+
+// dictionaryNames are the custom dictionaries of an application.
+var dictionaryNames = []string{"stopwords", "plurals", "compounds"}
+
+// withDictionaryName adds the argument selecting the dictionary to operate on.
+func withDictionaryName() mcp.ToolOption {
+	return mcp.WithString(
+		"dictionaryName",
+		mcp.Description("Dictionary type: stopwords, plurals or compounds"),
+		mcp.Enum(dictionaryNames...),
+		mcp.Required(),
+	)
+}
+
+func dictionaryName(args map[string]any) (string, error) {
+	name, _ := args["dictionaryName"].(string)
+	for _, n := range dictionaryNames {
+		if name == n {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("dictionaryName must be one of stopwords, plurals or compounds")
+}
@@ -0,0 +1,35 @@
+package dictionaries
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterGetLanguages registers the get_languages tool with the MCP server.
+func RegisterGetLanguages(mcps *server.MCPServer) {
+	getLanguagesTool := mcp.NewTool(
+		"dictionaries_get_languages",
+		mcp.WithDescription("List the languages supported by the dictionaries, with the number of custom entries in each dictionary"),
+	)
+
+	mcps.AddTool(getLanguagesTool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := algoliautil.CustomRequest(client, call.Read, http.MethodGet, "/1/dictionaries/*/languages", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dictionaries languages: %w", err)
+		}
+
+		return mcputil.JSONToolResult("Dictionaries Languages", res)
+	})
+}
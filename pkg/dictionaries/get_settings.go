@@ -0,0 +1,35 @@
+package dictionaries
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterGetSettings registers the get_settings tool with the MCP server.
+func RegisterGetSettings(mcps *server.MCPServer) {
+	getSettingsTool := mcp.NewTool(
+		"dictionaries_get_settings",
+		mcp.WithDescription("Retrieve the dictionaries settings, i.e. which languages have their standard entries disabled"),
+	)
+
+	mcps.AddTool(getSettingsTool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := algoliautil.CustomRequest(client, call.Read, http.MethodGet, "/1/dictionaries/*/settings", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dictionaries settings: %w", err)
+		}
+
+		return mcputil.JSONToolResult("Dictionaries Settings", res)
+	})
+}
@@ -0,0 +1,69 @@
+package dictionaries
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterSearchEntries registers the search_entries tool with the MCP server.
+func RegisterSearchEntries(mcps *server.MCPServer) {
+	searchEntriesTool := mcp.NewTool(
+		"dictionaries_search_entries",
+		mcp.WithDescription("Search for standard and custom entries in the stopwords, plurals or compounds dictionary"),
+		withDictionaryName(),
+		mcp.WithString(
+			"query",
+			mcp.Description("Text to search for in the dictionary entries"),
+		),
+		mcp.WithString(
+			"language",
+			mcp.Description("ISO code of the language to search in (e.g., 'de', 'fr')"),
+		),
+		mcp.WithNumber(
+			"page",
+			mcp.Description("Page of search results to retrieve"),
+		),
+		mcp.WithNumber(
+			"hitsPerPage",
+			mcp.Description("Number of hits per page"),
+		),
+	)
+
+	mcps.AddTool(searchEntriesTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := dictionaryName(req.Params.Arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		query, _ := req.Params.Arguments["query"].(string)
+		body := map[string]any{"query": query}
+		if language, ok := req.Params.Arguments["language"].(string); ok && language != "" {
+			body["language"] = language
+		}
+		if page, ok := req.Params.Arguments["page"].(float64); ok {
+			body["page"] = int(page)
+		}
+		if hitsPerPage, ok := req.Params.Arguments["hitsPerPage"].(float64); ok {
+			body["hitsPerPage"] = int(hitsPerPage)
+		}
+
+		res, err := algoliautil.CustomRequest(client, call.Read, http.MethodPost, fmt.Sprintf("/1/dictionaries/%s/search", name), body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search dictionary entries: %w", err)
+		}
+
+		return mcputil.JSONToolResult("Dictionary Entries", res)
+	})
+}
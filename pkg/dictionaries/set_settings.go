@@ -0,0 +1,67 @@
+package dictionaries
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterSetSettings registers the set_settings tool with the MCP server.
+func RegisterSetSettings(mcps *server.MCPServer) {
+	setSettingsTool := mcp.NewTool(
+		"dictionaries_set_settings",
+		mcp.WithDescription("Enable or disable the standard entries of a dictionary for a language"),
+		withDictionaryName(),
+		mcp.WithString(
+			"language",
+			mcp.Description("ISO code of the language (e.g., 'de', 'fr')"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean(
+			"disableStandardEntries",
+			mcp.Description("Whether to disable the standard entries, so that only custom entries are used"),
+			mcp.Required(),
+		),
+	)
+
+	mcps.AddTool(setSettingsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := dictionaryName(req.Params.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		language, _ := req.Params.Arguments["language"].(string)
+		if language == "" {
+			return nil, fmt.Errorf("language parameter is required")
+		}
+		disable, ok := req.Params.Arguments["disableStandardEntries"].(bool)
+		if !ok {
+			return nil, fmt.Errorf("disableStandardEntries parameter is required")
+		}
+
+		// Only the given dictionary and language are changed, the others
+		// keep their current settings.
+		body := map[string]any{
+			"disableStandardEntries": map[string]any{
+				name: map[string]any{language: disable},
+			},
+		}
+
+		res, err := algoliautil.CustomRequest(client, call.Write, http.MethodPut, "/1/dictionaries/*/settings", body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set dictionaries settings: %w", err)
+		}
+
+		return mcputil.JSONToolResult("Dictionaries Settings Updated", res)
+	})
+}
@@ -0,0 +1,45 @@
+package insights
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterDeleteUserToken registers the delete_user_token tool with the MCP server.
+func RegisterDeleteUserToken(mcps *server.MCPServer) {
+	deleteUserTokenTool := mcp.NewTool(
+		"insights_delete_user_token",
+		mcp.WithDescription("Delete all events related to a user token, e.g. for a GDPR erasure request. Deleted events can't be recovered"),
+		mcp.WithString(
+			"userToken",
+			mcp.Description("The user token whose events to delete"),
+			mcp.Required(),
+		),
+		withRegion(),
+	)
+
+	mcps.AddTool(deleteUserTokenTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		userToken, _ := req.Params.Arguments["userToken"].(string)
+		if !userTokenPattern.MatchString(userToken) {
+			return nil, fmt.Errorf("userToken must be 1 to 129 letters, digits or characters among _=/+-")
+		}
+		region, _ := req.Params.Arguments["region"].(string)
+
+		// Deleting events requires the deleteObject ACL
+		path := "/1/usertokens/" + url.PathEscape(userToken)
+		if err := request("ALGOLIA_WRITE_API_KEY", region, http.MethodDelete, path, nil, nil); err != nil {
+			return nil, fmt.Errorf("failed to delete user token: %w", err)
+		}
+
+		return mcputil.JSONToolResult("User Token Deleted", map[string]any{
+			"userToken": userToken,
+			"deleted":   true,
+		})
+	})
+}
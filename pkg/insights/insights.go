@@ -0,0 +1,48 @@
+package insights
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/mcp/pkg/algoliautil"
+)
+
+// RegisterAll registers all Insights tools with the MCP server.
+func RegisterAll(mcps *server.MCPServer) {
+	// Register all Insights tools.
+	RegisterSendEvents(mcps)
+	RegisterDeleteUserToken(mcps)
+}
+
+// withRegion adds the optional argument selecting the Insights API region.
+func withRegion() mcp.ToolOption {
+	return mcp.WithString(
+		"region",
+		mcp.Description("Analytics region of the application (us or de). When omitted, the request is routed to the region of the application"),
+		mcp.Enum("us", "de"),
+	)
+}
+
+// host returns the Insights API host for a region.
+func host(region string) (string, error) {
+	switch region {
+	case "":
+		return "https://insights.algolia.io", nil
+	case "us", "de":
+		return fmt.Sprintf("https://insights.%s.algolia.io", region), nil
+	default:
+		return "", fmt.Errorf("region must be 'us' or 'de'")
+	}
+}
+
+// request sends a request to the Insights API of a region, authenticated
+// with the API key read from the given environment variable.
+func request(apiKeyEnv, region, method, path string, body, v any) error {
+	base, err := host(region)
+	if err != nil {
+		return err
+	}
+	return algoliautil.Request(base, apiKeyEnv, method, path, body, v)
+}
@@ -0,0 +1,79 @@
+package insights
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// SendResult is the outcome of insights_send_events.
+type SendResult struct {
+	Sent   bool           `json:"sent"`
+	Events int            `json:"events"`
+	Kinds  map[string]int `json:"kinds"`
+	Result any            `json:"result,omitempty"`
+}
+
+// RegisterSendEvents registers the send_events tool with the MCP server.
+func RegisterSendEvents(mcps *server.MCPServer) {
+	sendEventsTool := mcp.NewTool(
+		"insights_send_events",
+		mcp.WithDescription("Send click, conversion and view events to the Insights API (clickedObjectIDsAfterSearch, convertedObjectIDs, addedToCartObjectIDs, purchasedObjectIDs, viewedFilters, etc.). Events are validated locally first (required fields, queryID format, positions matching objectIDs, timestamps within the last 4 days), and nothing is sent if any event is invalid"),
+		mcp.WithString(
+			"events",
+			mcp.Description("Events as a JSON array, in the Insights API format. Example: [{\"eventType\":\"click\",\"eventName\":\"Product Clicked\",\"index\":\"products\",\"userToken\":\"user-1\",\"queryID\":\"43b15df305339e827f0ac0bdc5ebcaa7\",\"objectIDs\":[\"9780545139700\"],\"positions\":[7]}]"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean(
+			"validateOnly",
+			mcp.Description("Only validate the events, without sending them"),
+		),
+		withRegion(),
+	)
+
+	mcps.AddTool(sendEventsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		eventsJSON, _ := req.Params.Arguments["events"].(string)
+		validateOnly, _ := req.Params.Arguments["validateOnly"].(bool)
+		region, _ := req.Params.Arguments["region"].(string)
+
+		var events []map[string]any
+		if err := json.Unmarshal([]byte(eventsJSON), &events); err != nil {
+			return nil, fmt.Errorf("invalid events JSON: %w", err)
+		}
+		if len(events) == 0 {
+			return nil, fmt.Errorf("at least one event is required")
+		}
+		if len(events) > maxEvents {
+			return nil, fmt.Errorf("at most %d events can be sent at once, got %d", maxEvents, len(events))
+		}
+
+		if invalid := validateEvents(events, time.Now()); len(invalid) > 0 {
+			b, _ := json.MarshalIndent(invalid, "", "  ")
+			return mcp.NewToolResultError(fmt.Sprintf("%d of %d events are invalid, no event was sent:\n%s", len(invalid), len(events), b)), nil
+		}
+
+		res := SendResult{Events: len(events), Kinds: map[string]int{}}
+		for _, e := range events {
+			res.Kinds[eventKind(e)]++
+		}
+		if validateOnly {
+			return mcputil.JSONToolResult("Events Validated", res)
+		}
+
+		var result map[string]any
+		body := map[string]any{"events": events}
+		if err := request("ALGOLIA_API_KEY", region, http.MethodPost, "/1/events", body, &result); err != nil {
+			return nil, fmt.Errorf("failed to send events: %w", err)
+		}
+		res.Sent = true
+		res.Result = result
+
+		return mcputil.JSONToolResult("Events Sent", res)
+	})
+}
@@ -0,0 +1,212 @@
+package insights
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Limits of the Insights API.
+const (
+	maxEvents     = 1000
+	maxObjectIDs  = 20
+	maxFilters    = 10
+	maxEventName  = 64
+	maxEventAge   = 4 * 24 * time.Hour
+	maxClockDrift = time.Hour
+)
+
+var (
+	queryIDPattern   = regexp.MustCompile(`^[0-9a-f]{32}$`)
+	userTokenPattern = regexp.MustCompile(`^[a-zA-Z0-9_=/+-]{1,129}$`)
+	eventNamePattern = regexp.MustCompile(`^[\x20-\x7E]+$`)
+	currencyPattern  = regexp.MustCompile(`^[A-Z]{3}$`)
+	filterPattern    = regexp.MustCompile(`^[^:]+:.+$`)
+)
+
+// FieldError is an invalid field of an event.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// EventError lists the invalid fields of an event.
+type EventError struct {
+	Index  int          `json:"index"`
+	Kind   string       `json:"kind,omitempty"`
+	Errors []FieldError `json:"errors"`
+}
+
+// eventKind returns the kind of an event, as named by the Insights API
+// (e.g., clickedObjectIDsAfterSearch), from its eventType, eventSubtype and
+// fields.
+func eventKind(e map[string]any) string {
+	_, hasQueryID := e["queryID"]
+	_, hasFilters := e["filters"]
+	afterSearch := ""
+	if hasQueryID {
+		afterSearch = "AfterSearch"
+	}
+	switch e["eventType"] {
+	case "click":
+		if hasFilters {
+			return "clickedFilters"
+		}
+		return "clickedObjectIDs" + afterSearch
+	case "conversion":
+		switch e["eventSubtype"] {
+		case "addToCart":
+			return "addedToCartObjectIDs" + afterSearch
+		case "purchase":
+			return "purchasedObjectIDs" + afterSearch
+		}
+		if hasFilters {
+			return "convertedFilters"
+		}
+		return "convertedObjectIDs" + afterSearch
+	case "view":
+		if hasFilters {
+			return "viewedFilters"
+		}
+		return "viewedObjectIDs"
+	}
+	return ""
+}
+
+// validateEvent checks an event against the Insights API schema and limits.
+func validateEvent(e map[string]any, now time.Time) (string, []FieldError) {
+	var errs []FieldError
+	fail := func(field, format string, a ...any) {
+		errs = append(errs, FieldError{Field: field, Message: fmt.Sprintf(format, a...)})
+	}
+
+	kind := eventKind(e)
+	if kind == "" {
+		fail("eventType", "must be click, conversion or view")
+	}
+	if sub, ok := e["eventSubtype"]; ok && (e["eventType"] != "conversion" || (sub != "addToCart" && sub != "purchase")) {
+		fail("eventSubtype", "must be addToCart or purchase, on a conversion event")
+	}
+
+	switch name, _ := e["eventName"].(string); {
+	case name == "":
+		fail("eventName", "is required")
+	case len(name) > maxEventName:
+		fail("eventName", "must be at most %d characters", maxEventName)
+	case !eventNamePattern.MatchString(name):
+		fail("eventName", "must only contain printable ASCII characters")
+	}
+	if index, _ := e["index"].(string); index == "" {
+		fail("index", "is required")
+	}
+	if token, _ := e["userToken"].(string); !userTokenPattern.MatchString(token) {
+		fail("userToken", "is required, and must be 1 to 129 letters, digits or characters among _=/+-")
+	}
+	if v, ok := e["authenticatedUserToken"]; ok {
+		if token, _ := v.(string); !userTokenPattern.MatchString(token) {
+			fail("authenticatedUserToken", "must be 1 to 129 letters, digits or characters among _=/+-")
+		}
+	}
+
+	if v, ok := e["timestamp"]; ok {
+		ms, ok := v.(float64)
+		if !ok || ms != float64(int64(ms)) {
+			fail("timestamp", "must be a number of milliseconds since the Unix epoch")
+		} else {
+			t := time.UnixMilli(int64(ms))
+			switch {
+			case t.Before(now.Add(-maxEventAge)):
+				fail("timestamp", "is more than 4 days in the past, the event would be rejected")
+			case t.After(now.Add(maxClockDrift)):
+				fail("timestamp", "is in the future")
+			}
+		}
+	}
+
+	if v, ok := e["queryID"]; ok {
+		if id, _ := v.(string); !queryIDPattern.MatchString(id) {
+			fail("queryID", "must be the 32 lowercase hexadecimal characters returned by a search with clickAnalytics")
+		}
+	}
+
+	objectIDs, _ := e["objectIDs"].([]any)
+	filters, _ := e["filters"].([]any)
+	_, hasObjectIDs := e["objectIDs"]
+	_, hasFilters := e["filters"]
+	switch {
+	case hasObjectIDs && hasFilters:
+		fail("filters", "can't be sent with objectIDs")
+	case hasFilters:
+		if len(filters) == 0 || len(filters) > maxFilters {
+			fail("filters", "must have 1 to %d filters", maxFilters)
+		}
+		for i, f := range filters {
+			if s, _ := f.(string); !filterPattern.MatchString(s) {
+				fail(fmt.Sprintf("filters[%d]", i), "must be a facet filter such as brand:apple")
+			}
+		}
+	case kind != "":
+		if len(objectIDs) == 0 || len(objectIDs) > maxObjectIDs {
+			fail("objectIDs", "is required, with 1 to %d objectIDs", maxObjectIDs)
+		}
+		for i, id := range objectIDs {
+			if s, _ := id.(string); s == "" {
+				fail(fmt.Sprintf("objectIDs[%d]", i), "must be a non-empty string")
+			}
+		}
+	}
+
+	positions, _ := e["positions"].([]any)
+	_, hasPositions := e["positions"]
+	switch {
+	case kind == "clickedObjectIDsAfterSearch" && !hasPositions:
+		fail("positions", "is required on clicks after a search")
+	case hasPositions && kind != "clickedObjectIDsAfterSearch":
+		fail("positions", "is only allowed on clicks after a search")
+	case hasPositions && len(positions) != len(objectIDs):
+		fail("positions", "must have as many items as objectIDs (%d, got %d)", len(objectIDs), len(positions))
+	}
+	for i, p := range positions {
+		if n, ok := p.(float64); !ok || n < 1 || n != float64(int64(n)) {
+			fail(fmt.Sprintf("positions[%d]", i), "must be a 1-based position")
+		}
+	}
+
+	if v, ok := e["objectData"]; ok {
+		data, ok := v.([]any)
+		switch {
+		case !ok:
+			fail("objectData", "must be an array")
+		case len(data) != len(objectIDs):
+			fail("objectData", "must have as many items as objectIDs (%d, got %d)", len(objectIDs), len(data))
+		}
+		for i, d := range data {
+			if m, ok := d.(map[string]any); ok {
+				if id, ok := m["queryID"]; ok {
+					if s, _ := id.(string); !queryIDPattern.MatchString(s) {
+						fail(fmt.Sprintf("objectData[%d].queryID", i), "must be 32 lowercase hexadecimal characters")
+					}
+				}
+			}
+		}
+	}
+	if _, ok := e["value"]; ok {
+		if c, _ := e["currency"].(string); !currencyPattern.MatchString(c) {
+			fail("currency", "is required with a value, as an ISO 4217 code such as USD")
+		}
+	}
+
+	return kind, errs
+}
+
+// validateEvents checks a batch of events.
+func validateEvents(events []map[string]any, now time.Time) []EventError {
+	var invalid []EventError
+	for i, e := range events {
+		kind, errs := validateEvent(e, now)
+		if len(errs) > 0 {
+			invalid = append(invalid, EventError{Index: i, Kind: kind, Errors: errs})
+		}
+	}
+	return invalid
+}
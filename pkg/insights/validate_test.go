@@ -0,0 +1,126 @@
+package insights
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidateEvent(t *testing.T) {
+	// now is 2025-03-15T00:00:00Z, and timestamps are in milliseconds.
+	now := time.UnixMilli(1741996800000).UTC()
+	tests := []struct {
+		name   string
+		event  string
+		kind   string
+		fields []string
+	}{
+		{
+			name:  "valid click after search",
+			event: `{"eventType": "click", "queryID": "0123456789abcdef0123456789abcdef", "objectIDs": ["a", "b"], "positions": [1, 2], "timestamp": 1741910400000}`,
+			kind:  "clickedObjectIDsAfterSearch",
+		},
+		{
+			name:   "uppercase queryID",
+			event:  `{"eventType": "conversion", "queryID": "0123456789ABCDEF0123456789ABCDEF", "objectIDs": ["a"]}`,
+			kind:   "convertedObjectIDsAfterSearch",
+			fields: []string{"queryID"},
+		},
+		{
+			name:   "short queryID",
+			event:  `{"eventType": "conversion", "eventSubtype": "purchase", "queryID": "0123", "objectIDs": ["a"]}`,
+			kind:   "purchasedObjectIDsAfterSearch",
+			fields: []string{"queryID"},
+		},
+		{
+			name:   "fewer positions than objectIDs",
+			event:  `{"eventType": "click", "queryID": "0123456789abcdef0123456789abcdef", "objectIDs": ["a", "b"], "positions": [1]}`,
+			kind:   "clickedObjectIDsAfterSearch",
+			fields: []string{"positions"},
+		},
+		{
+			name:   "missing positions",
+			event:  `{"eventType": "click", "queryID": "0123456789abcdef0123456789abcdef", "objectIDs": ["a"]}`,
+			kind:   "clickedObjectIDsAfterSearch",
+			fields: []string{"positions"},
+		},
+		{
+			name:   "positions without a search",
+			event:  `{"eventType": "view", "objectIDs": ["a"], "positions": [1]}`,
+			kind:   "viewedObjectIDs",
+			fields: []string{"positions"},
+		},
+		{
+			name:   "zero position",
+			event:  `{"eventType": "click", "queryID": "0123456789abcdef0123456789abcdef", "objectIDs": ["a"], "positions": [0]}`,
+			kind:   "clickedObjectIDsAfterSearch",
+			fields: []string{"positions[0]"},
+		},
+		{
+			name:   "timestamp more than 4 days old",
+			event:  `{"eventType": "view", "objectIDs": ["a"], "timestamp": 1741564800000}`,
+			kind:   "viewedObjectIDs",
+			fields: []string{"timestamp"},
+		},
+		{
+			name:  "timestamp within the clock drift",
+			event: `{"eventType": "view", "objectIDs": ["a"], "timestamp": 1741998600000}`,
+			kind:  "viewedObjectIDs",
+		},
+		{
+			name:   "timestamp in the future",
+			event:  `{"eventType": "view", "objectIDs": ["a"], "timestamp": 1742004000000}`,
+			kind:   "viewedObjectIDs",
+			fields: []string{"timestamp"},
+		},
+		{
+			name:   "fractional timestamp",
+			event:  `{"eventType": "view", "objectIDs": ["a"], "timestamp": 1741996800.5}`,
+			kind:   "viewedObjectIDs",
+			fields: []string{"timestamp"},
+		},
+		{
+			name:  "valid filters",
+			event: `{"eventType": "click", "filters": ["brand:apple", "category:phones"]}`,
+			kind:  "clickedFilters",
+		},
+		{
+			name:   "filters with objectIDs",
+			event:  `{"eventType": "view", "filters": ["brand:apple"], "objectIDs": ["a"]}`,
+			kind:   "viewedFilters",
+			fields: []string{"filters"},
+		},
+		{
+			name:   "filter without a facet",
+			event:  `{"eventType": "conversion", "filters": ["apple"]}`,
+			kind:   "convertedFilters",
+			fields: []string{"filters[0]"},
+		},
+		{
+			name:   "missing objectIDs and filters",
+			event:  `{"eventType": "click"}`,
+			kind:   "clickedObjectIDs",
+			fields: []string{"objectIDs"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := map[string]any{"eventName": "Product Clicked", "index": "products", "userToken": "user-1"}
+			if err := json.Unmarshal([]byte(tt.event), &e); err != nil {
+				t.Fatal(err)
+			}
+			kind, errs := validateEvent(e, now)
+			if kind != tt.kind {
+				t.Errorf("kind = %q, want %q", kind, tt.kind)
+			}
+			var fields []string
+			for _, e := range errs {
+				fields = append(fields, e.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("error fields = %q, want %q (errors: %v)", fields, tt.fields, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterCreateKey registers the create_key tool with the MCP server.
+func RegisterCreateKey(mcps *server.MCPServer) {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("Create an API key, e.g. a restricted search key with a validity, referers and rate limits. The new key value is masked unless revealValues is set"),
+	}
+	opts = append(opts, keyFieldOptions()...)
+	opts = append(opts, withRevealValues())
+	createKeyTool := mcp.NewTool("keys_create_key", opts...)
+
+	mcps.AddTool(createKeyTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+		reveal, _ := req.Params.Arguments["revealValues"].(bool)
+
+		body := keyBody(req.Params.Arguments)
+		if _, ok := body["acl"]; !ok {
+			return nil, fmt.Errorf("acl parameter is required")
+		}
+
+		res, err := algoliautil.CustomRequest(client, call.Write, http.MethodPost, "/1/keys", body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create API key: %w", err)
+		}
+
+		return mcputil.JSONToolResult("API Key Created", maskFields(res, reveal, "key"))
+	})
+}
@@ -0,0 +1,43 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterDecodeSecuredKey registers the decode_secured_api_key tool with the MCP server.
+func RegisterDecodeSecuredKey(mcps *server.MCPServer) {
+	decodeSecuredKeyTool := mcp.NewTool(
+		"keys_decode_secured_api_key",
+		mcp.WithDescription("Reveal the filters, restrictions and expiry embedded in a secured API key, e.g. to diagnose why a user sees the wrong results. Computed locally, without any API call"),
+		mcp.WithString(
+			"securedAPIKey",
+			mcp.Description("The secured API key to decode"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"parentAPIKey",
+			mcp.Description("The parent search API key, to check that the secured key was generated from it"),
+		),
+	)
+
+	mcps.AddTool(decodeSecuredKeyTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		key, _ := req.Params.Arguments["securedAPIKey"].(string)
+		if key == "" {
+			return nil, fmt.Errorf("securedAPIKey parameter is required")
+		}
+		parentKey, _ := req.Params.Arguments["parentAPIKey"].(string)
+
+		info, err := decodeSecuredKey(key, parentKey, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		return mcputil.JSONToolResult("Secured API Key", info)
+	})
+}
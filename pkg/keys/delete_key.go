@@ -0,0 +1,45 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterDeleteKey registers the delete_key tool with the MCP server.
+func RegisterDeleteKey(mcps *server.MCPServer) {
+	deleteKeyTool := mcp.NewTool(
+		"keys_delete_key",
+		mcp.WithDescription("Delete an API key. Deleted keys can be restored with keys_restore_key"),
+		mcp.WithString(
+			"key",
+			mcp.Description("The API key value to delete"),
+			mcp.Required(),
+		),
+	)
+
+	mcps.AddTool(deleteKeyTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		key, _ := req.Params.Arguments["key"].(string)
+		if key == "" {
+			return nil, fmt.Errorf("key parameter is required")
+		}
+
+		res, err := algoliautil.CustomRequest(client, call.Write, http.MethodDelete, keyPath(key), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete API key: %w", err)
+		}
+
+		return mcputil.JSONToolResult("API Key Deleted", res)
+	})
+}
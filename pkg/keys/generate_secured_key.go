@@ -0,0 +1,102 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterGenerateSecuredKey registers the generate_secured_api_key tool with the MCP server.
+func RegisterGenerateSecuredKey(mcps *server.MCPServer) {
+	generateSecuredKeyTool := mcp.NewTool(
+		"keys_generate_secured_api_key",
+		mcp.WithDescription("Generate a secured API key from a parent search key, embedding filters and restrictions. Computed locally, without any API call"),
+		mcp.WithString(
+			"parentAPIKey",
+			mcp.Description("The search API key the secured key is derived from (defaults to ALGOLIA_API_KEY)"),
+		),
+		mcp.WithString(
+			"filters",
+			mcp.Description("Filters applied to every search made with the key (e.g., 'tenant:acme AND visible:true')"),
+		),
+		mcp.WithNumber(
+			"validUntil",
+			mcp.Description("Unix timestamp after which the key expires"),
+		),
+		mcp.WithNumber(
+			"validFor",
+			mcp.Description("Number of seconds from now after which the key expires, used when validUntil is not set"),
+		),
+		mcp.WithString(
+			"restrictIndices",
+			mcp.Description("Comma-separated index names or patterns the key can search (e.g., 'products,products_*')"),
+		),
+		mcp.WithString(
+			"restrictSources",
+			mcp.Description("IPv4 network allowed to use the key, in CIDR notation (e.g., '192.168.1.0/24')"),
+		),
+		mcp.WithString(
+			"userToken",
+			mcp.Description("User identifier used for rate limiting and analytics"),
+		),
+		mcp.WithString(
+			"referers",
+			mcp.Description("Comma-separated allowed HTTP referers (e.g., 'https://algolia.com/*')"),
+		),
+	)
+
+	mcps.AddTool(generateSecuredKeyTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		parentKey, _ := req.Params.Arguments["parentAPIKey"].(string)
+		if parentKey == "" {
+			parentKey = os.Getenv("ALGOLIA_API_KEY")
+		}
+		if parentKey == "" {
+			return nil, fmt.Errorf("parentAPIKey parameter or ALGOLIA_API_KEY environment variable is required")
+		}
+
+		opts := []any{}
+		if filters, ok := req.Params.Arguments["filters"].(string); ok && filters != "" {
+			opts = append(opts, opt.Filters(filters))
+		}
+		if validUntil, ok := req.Params.Arguments["validUntil"].(float64); ok {
+			opts = append(opts, opt.ValidUntil(time.Unix(int64(validUntil), 0)))
+		} else if validFor, ok := req.Params.Arguments["validFor"].(float64); ok {
+			opts = append(opts, opt.ValidUntil(time.Now().Add(time.Duration(validFor)*time.Second)))
+		}
+		if indices, ok := req.Params.Arguments["restrictIndices"].(string); ok && indices != "" {
+			opts = append(opts, opt.RestrictIndices(mcputil.SplitList(indices)...))
+		}
+		if sources, ok := req.Params.Arguments["restrictSources"].(string); ok && sources != "" {
+			opts = append(opts, opt.RestrictSources(sources))
+		}
+		if userToken, ok := req.Params.Arguments["userToken"].(string); ok && userToken != "" {
+			opts = append(opts, opt.UserToken(userToken))
+		}
+		if referers, ok := req.Params.Arguments["referers"].(string); ok && referers != "" {
+			opts = append(opts, opt.Referers(mcputil.SplitList(referers)...))
+		}
+
+		key, err := search.GenerateSecuredAPIKey(parentKey, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate secured API key: %w", err)
+		}
+
+		info, err := decodeSecuredKey(key, parentKey, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		return mcputil.JSONToolResult("Secured API Key", map[string]any{
+			"securedAPIKey": key,
+			"restrictions":  info.Restrictions,
+			"expiresAt":     info.ExpiresAt,
+		})
+	})
+}
@@ -0,0 +1,47 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterGetKey registers the get_key tool with the MCP server.
+func RegisterGetKey(mcps *server.MCPServer) {
+	getKeyTool := mcp.NewTool(
+		"keys_get_key",
+		mcp.WithDescription("Retrieve the permissions and restrictions of an API key"),
+		mcp.WithString(
+			"key",
+			mcp.Description("The API key value"),
+			mcp.Required(),
+		),
+		withRevealValues(),
+	)
+
+	mcps.AddTool(getKeyTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		key, _ := req.Params.Arguments["key"].(string)
+		if key == "" {
+			return nil, fmt.Errorf("key parameter is required")
+		}
+		reveal, _ := req.Params.Arguments["revealValues"].(bool)
+
+		res, err := algoliautil.CustomRequest(client, call.Read, http.MethodGet, keyPath(key), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get API key: %w", err)
+		}
+
+		return mcputil.JSONToolResult("API Key", maskFields(res, reveal, "value"))
+	})
+}
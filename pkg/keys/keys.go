@@ -0,0 +1,135 @@
+package keys
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterReadAll registers the API key tools that don't change the
+// application with the MCP server.
+func RegisterReadAll(mcps *server.MCPServer) {
+	RegisterListKeys(mcps)
+	RegisterGetKey(mcps)
+	RegisterGenerateSecuredKey(mcps)
+	RegisterDecodeSecuredKey(mcps)
+}
+
+// RegisterAll registers all API key tools with the MCP server, including
+// the ones that create, change and delete keys.
+func RegisterAll(mcps *server.MCPServer) {
+	RegisterReadAll(mcps)
+	RegisterCreateKey(mcps)
+	RegisterUpdateKey(mcps)
+	RegisterRotateKey(mcps)
+	RegisterDeleteKey(mcps)
+	RegisterRestoreKey(mcps)
+}
+
+// keyFields are the API key properties that can be set on creation or update.
+var keyFields = []string{
+	"acl",
+	"description",
+	"indexes",
+	"maxHitsPerQuery",
+	"maxQueriesPerIPPerHour",
+	"queryParameters",
+	"referers",
+	"validity",
+}
+
+func keyPath(key string) string {
+	return "/1/keys/" + url.PathEscape(key)
+}
+
+// maskKey hides all but the first and last 4 characters of a key value.
+func maskKey(v string) string {
+	if len(v) <= 8 {
+		return strings.Repeat("*", len(v))
+	}
+	return v[:4] + strings.Repeat("*", len(v)-8) + v[len(v)-4:]
+}
+
+// maskFields masks the key values found in the given fields of a response,
+// unless reveal is set.
+func maskFields(m map[string]any, reveal bool, fields ...string) map[string]any {
+	if reveal {
+		return m
+	}
+	for _, f := range fields {
+		if v, ok := m[f].(string); ok {
+			m[f] = maskKey(v)
+		}
+	}
+	return m
+}
+
+// keyBody builds a create or update request body from the tool arguments.
+func keyBody(args map[string]any) map[string]any {
+	body := map[string]any{}
+	for _, f := range []string{"acl", "indexes", "referers"} {
+		if s, ok := args[f].(string); ok && s != "" {
+			body[f] = mcputil.SplitList(s)
+		}
+	}
+	for _, f := range []string{"description", "queryParameters"} {
+		if s, ok := args[f].(string); ok && s != "" {
+			body[f] = s
+		}
+	}
+	for _, f := range []string{"validity", "maxHitsPerQuery", "maxQueriesPerIPPerHour"} {
+		if n, ok := args[f].(float64); ok {
+			body[f] = int64(n)
+		}
+	}
+	return body
+}
+
+// keyFieldOptions are the tool arguments describing the properties of a key.
+func keyFieldOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString(
+			"acl",
+			mcp.Description("Comma-separated permissions of the key (e.g., 'search,browse'). Possible values: addObject, analytics, browse, deleteObject, deleteIndex, editSettings, inference, listIndexes, logs, personalization, recommendation, search, seeUnretrievableAttributes, settings, usage"),
+		),
+		mcp.WithString(
+			"description",
+			mcp.Description("Description of the key"),
+		),
+		mcp.WithString(
+			"indexes",
+			mcp.Description("Comma-separated index names or patterns the key can access (e.g., 'products,dev_*'). All indices when omitted"),
+		),
+		mcp.WithString(
+			"referers",
+			mcp.Description("Comma-separated allowed HTTP referers (e.g., 'https://algolia.com/*')"),
+		),
+		mcp.WithNumber(
+			"validity",
+			mcp.Description("Duration in seconds after which the key expires. Never expires when 0 or omitted"),
+		),
+		mcp.WithNumber(
+			"maxHitsPerQuery",
+			mcp.Description("Maximum number of hits the key can retrieve in one call"),
+		),
+		mcp.WithNumber(
+			"maxQueriesPerIPPerHour",
+			mcp.Description("Maximum number of API calls per hour allowed from a given IP address"),
+		),
+		mcp.WithString(
+			"queryParameters",
+			mcp.Description("Search parameters applied to every query made with the key, URL-encoded (e.g., 'filters=brand:Nike&typoTolerance=strict')"),
+		),
+	}
+}
+
+// withRevealValues adds the argument that disables the masking of key values.
+func withRevealValues() mcp.ToolOption {
+	return mcp.WithBoolean(
+		"revealValues",
+		mcp.Description("Return key values in full instead of masked. Only use when the value is needed"),
+	)
+}
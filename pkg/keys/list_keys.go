@@ -0,0 +1,44 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterListKeys registers the list_keys tool with the MCP server.
+func RegisterListKeys(mcps *server.MCPServer) {
+	listKeysTool := mcp.NewTool(
+		"keys_list_keys",
+		mcp.WithDescription("List the API keys of the application with their permissions, indices and restrictions. Key values are masked unless revealValues is set"),
+		withRevealValues(),
+	)
+
+	mcps.AddTool(listKeysTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+		reveal, _ := req.Params.Arguments["revealValues"].(bool)
+
+		res, err := algoliautil.CustomRequest(client, call.Read, http.MethodGet, "/1/keys", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list API keys: %w", err)
+		}
+
+		keys, _ := res["keys"].([]any)
+		for _, k := range keys {
+			if m, ok := k.(map[string]any); ok {
+				maskFields(m, reveal, "value")
+			}
+		}
+
+		return mcputil.JSONToolResult("API Keys", res)
+	})
+}
@@ -0,0 +1,45 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterRestoreKey registers the restore_key tool with the MCP server.
+func RegisterRestoreKey(mcps *server.MCPServer) {
+	restoreKeyTool := mcp.NewTool(
+		"keys_restore_key",
+		mcp.WithDescription("Restore a deleted API key, with its permissions and restrictions"),
+		mcp.WithString(
+			"key",
+			mcp.Description("The value of the deleted API key"),
+			mcp.Required(),
+		),
+	)
+
+	mcps.AddTool(restoreKeyTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		key, _ := req.Params.Arguments["key"].(string)
+		if key == "" {
+			return nil, fmt.Errorf("key parameter is required")
+		}
+
+		res, err := algoliautil.CustomRequest(client, call.Write, http.MethodPost, keyPath(key)+"/restore", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore API key: %w", err)
+		}
+
+		return mcputil.JSONToolResult("API Key Restored", res)
+	})
+}
@@ -0,0 +1,77 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterRotateKey registers the rotate_key tool with the MCP server.
+func RegisterRotateKey(mcps *server.MCPServer) {
+	rotateKeyTool := mcp.NewTool(
+		"keys_rotate_key",
+		mcp.WithDescription("Rotate an API key by creating a new key with the same permissions and restrictions, and optionally deleting the old one"),
+		mcp.WithString(
+			"key",
+			mcp.Description("The API key value to rotate"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean(
+			"deleteOld",
+			mcp.Description("Delete the old key once the new one is created. The old key can be restored with keys_restore_key"),
+		),
+		withRevealValues(),
+	)
+
+	mcps.AddTool(rotateKeyTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		key, _ := req.Params.Arguments["key"].(string)
+		if key == "" {
+			return nil, fmt.Errorf("key parameter is required")
+		}
+		deleteOld, _ := req.Params.Arguments["deleteOld"].(bool)
+		reveal, _ := req.Params.Arguments["revealValues"].(bool)
+
+		old, err := algoliautil.CustomRequest(client, call.Read, http.MethodGet, keyPath(key), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get API key: %w", err)
+		}
+
+		body := map[string]any{}
+		for _, f := range keyFields {
+			if v, ok := old[f]; ok {
+				body[f] = v
+			}
+		}
+
+		created, err := algoliautil.CustomRequest(client, call.Write, http.MethodPost, "/1/keys", body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create API key: %w", err)
+		}
+
+		result := map[string]any{
+			"oldKey":    key,
+			"newKey":    created["key"],
+			"createdAt": created["createdAt"],
+			"deleted":   false,
+		}
+		if deleteOld {
+			if _, err := algoliautil.CustomRequest(client, call.Write, http.MethodDelete, keyPath(key), nil); err != nil {
+				return nil, fmt.Errorf("new key created but failed to delete old API key: %w", err)
+			}
+			result["deleted"] = true
+		}
+
+		return mcputil.JSONToolResult("API Key Rotated", maskFields(result, reveal, "oldKey", "newKey"))
+	})
+}
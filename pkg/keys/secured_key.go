@@ -0,0 +1,78 @@
+package keys
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// SecuredKeyInfo is the content of a secured API key.
+type SecuredKeyInfo struct {
+	Restrictions   map[string]any `json:"restrictions"`
+	ValidUntil     int64          `json:"validUntil,omitempty"`
+	ExpiresAt      *time.Time     `json:"expiresAt,omitempty"`
+	Expired        bool           `json:"expired"`
+	RemainingTime  string         `json:"remainingTime,omitempty"`
+	SignatureValid *bool          `json:"signatureValid,omitempty"`
+}
+
+// decodeSecuredKey extracts the restrictions embedded in a secured API key.
+// A secured key is the base64 encoding of the hex HMAC-SHA256 of its
+// URL-encoded restrictions, followed by the restrictions themselves. When a
+// parent key is given, the HMAC is checked against it.
+func decodeSecuredKey(key, parentKey string, now time.Time) (SecuredKeyInfo, error) {
+	var info SecuredKeyInfo
+
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return info, fmt.Errorf("not a secured API key: invalid base64: %w", err)
+	}
+	if len(decoded) < sha256.Size*2 {
+		return info, fmt.Errorf("not a secured API key: too short")
+	}
+	checksum, message := string(decoded[:sha256.Size*2]), string(decoded[sha256.Size*2:])
+	if _, err := hex.DecodeString(checksum); err != nil {
+		return info, fmt.Errorf("not a secured API key: invalid checksum")
+	}
+
+	params, err := url.ParseQuery(message)
+	if err != nil {
+		return info, fmt.Errorf("invalid restrictions %q: %w", message, err)
+	}
+	info.Restrictions = make(map[string]any, len(params))
+	for k, v := range params {
+		if len(v) == 1 {
+			info.Restrictions[k] = v[0]
+		} else {
+			info.Restrictions[k] = v
+		}
+	}
+
+	if v := params.Get("validUntil"); v != "" {
+		ts, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return info, fmt.Errorf("invalid validUntil %q: %w", v, err)
+		}
+		expiresAt := time.Unix(ts, 0).UTC()
+		info.ValidUntil = ts
+		info.ExpiresAt = &expiresAt
+		info.Expired = !now.Before(expiresAt)
+		if !info.Expired {
+			info.RemainingTime = expiresAt.Sub(now).Round(time.Second).String()
+		}
+	}
+
+	if parentKey != "" {
+		h := hmac.New(sha256.New, []byte(parentKey))
+		_, _ = h.Write([]byte(message))
+		valid := hmac.Equal([]byte(hex.EncodeToString(h.Sum(nil))), []byte(checksum))
+		info.SignatureValid = &valid
+	}
+
+	return info, nil
+}
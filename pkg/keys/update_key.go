@@ -0,0 +1,53 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterUpdateKey registers the update_key tool with the MCP server.
+func RegisterUpdateKey(mcps *server.MCPServer) {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("Replace the permissions and restrictions of an API key. Properties that are not provided are reset to their defaults"),
+		mcp.WithString(
+			"key",
+			mcp.Description("The API key value"),
+			mcp.Required(),
+		),
+	}
+	opts = append(opts, keyFieldOptions()...)
+	opts = append(opts, withRevealValues())
+	updateKeyTool := mcp.NewTool("keys_update_key", opts...)
+
+	mcps.AddTool(updateKeyTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		key, _ := req.Params.Arguments["key"].(string)
+		if key == "" {
+			return nil, fmt.Errorf("key parameter is required")
+		}
+		reveal, _ := req.Params.Arguments["revealValues"].(bool)
+
+		body := keyBody(req.Params.Arguments)
+		if _, ok := body["acl"]; !ok {
+			return nil, fmt.Errorf("acl parameter is required")
+		}
+
+		res, err := algoliautil.CustomRequest(client, call.Write, http.MethodPut, keyPath(key), body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update API key: %w", err)
+		}
+
+		return mcputil.JSONToolResult("API Key Updated", maskFields(res, reveal, "key"))
+	})
+}
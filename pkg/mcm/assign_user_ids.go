@@ -0,0 +1,63 @@
+package mcm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterAssignUserIDs registers the assign_user_ids tool with the MCP server.
+func RegisterAssignUserIDs(mcps *server.MCPServer) {
+	assignUserIDsTool := mcp.NewTool(
+		"mcm_assign_user_ids",
+		mcp.WithDescription("Assign one or more userIDs to a cluster. Moving existing userIDs to another cluster starts a migration, which can be followed with mcm_get_pending_mappings"),
+		mcp.WithString(
+			"userIDs",
+			mcp.Description("Comma-separated userIDs to assign"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"clusterName",
+			mcp.Description("The cluster to assign the userIDs to"),
+			mcp.Required(),
+		),
+	)
+
+	mcps.AddTool(assignUserIDsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		userIDsStr, _ := req.Params.Arguments["userIDs"].(string)
+		userIDs := mcputil.SplitList(userIDsStr)
+		if len(userIDs) == 0 {
+			return nil, fmt.Errorf("userIDs parameter is required")
+		}
+		clusterName, _ := req.Params.Arguments["clusterName"].(string)
+		if clusterName == "" {
+			return nil, fmt.Errorf("clusterName parameter is required")
+		}
+
+		// A single userID is assigned directly, several ones in a batch
+		var res any
+		if len(userIDs) == 1 {
+			res, err = client.AssignUserID(userIDs[0], clusterName)
+		} else {
+			res, err = client.AssignUserIDs(userIDs, clusterName)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to assign userIDs: %w", err)
+		}
+
+		return mcputil.JSONToolResult("UserIDs Assigned", map[string]any{
+			"clusterName": clusterName,
+			"userIDs":     userIDs,
+			"result":      res,
+		})
+	})
+}
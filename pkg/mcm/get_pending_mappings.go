@@ -0,0 +1,40 @@
+package mcm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterGetPendingMappings registers the get_pending_mappings tool with the MCP server.
+func RegisterGetPendingMappings(mcps *server.MCPServer) {
+	getPendingMappingsTool := mcp.NewTool(
+		"mcm_get_pending_mappings",
+		mcp.WithDescription("Check whether userID migrations between clusters are still pending"),
+		mcp.WithBoolean(
+			"getClusters",
+			mcp.Description("Also list the pending userIDs, grouped by cluster"),
+		),
+	)
+
+	mcps.AddTool(getPendingMappingsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		getClusters, _ := req.Params.Arguments["getClusters"].(bool)
+
+		res, err := client.HasPendingMappings(opt.RetrieveMappings(getClusters))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pending mappings: %w", err)
+		}
+
+		return mcputil.JSONToolResult("Pending Mappings", res)
+	})
+}
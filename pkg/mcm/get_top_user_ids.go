@@ -0,0 +1,33 @@
+package mcm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterGetTopUserIDs registers the get_top_user_ids tool with the MCP server.
+func RegisterGetTopUserIDs(mcps *server.MCPServer) {
+	getTopUserIDsTool := mcp.NewTool(
+		"mcm_get_top_user_ids",
+		mcp.WithDescription("List the userIDs with the most records, per cluster"),
+	)
+
+	mcps.AddTool(getTopUserIDsTool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := client.GetTopUserIDs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get top userIDs: %w", err)
+		}
+
+		return mcputil.JSONToolResult("Top UserIDs", res)
+	})
+}
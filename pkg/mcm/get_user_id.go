@@ -0,0 +1,43 @@
+package mcm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterGetUserID registers the get_user_id tool with the MCP server.
+func RegisterGetUserID(mcps *server.MCPServer) {
+	getUserIDTool := mcp.NewTool(
+		"mcm_get_user_id",
+		mcp.WithDescription("Find the cluster a userID is assigned to, with its number of records and data size"),
+		mcp.WithString(
+			"userID",
+			mcp.Description("The userID to look up"),
+			mcp.Required(),
+		),
+	)
+
+	mcps.AddTool(getUserIDTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		userID, _ := req.Params.Arguments["userID"].(string)
+		if userID == "" {
+			return nil, fmt.Errorf("userID parameter is required")
+		}
+
+		res, err := client.GetUserID(userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get userID: %w", err)
+		}
+
+		return mcputil.JSONToolResult("UserID", res)
+	})
+}
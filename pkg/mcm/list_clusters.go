@@ -0,0 +1,33 @@
+package mcm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterListClusters registers the list_clusters tool with the MCP server.
+func RegisterListClusters(mcps *server.MCPServer) {
+	listClustersTool := mcp.NewTool(
+		"mcm_list_clusters",
+		mcp.WithDescription("List the clusters of the application with their number of records, userIDs and data size"),
+	)
+
+	mcps.AddTool(listClustersTool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := client.ListClusters()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clusters: %w", err)
+		}
+
+		return mcputil.JSONToolResult("Clusters", res)
+	})
+}
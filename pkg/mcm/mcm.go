@@ -0,0 +1,17 @@
+package mcm
+
+import (
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterAll registers all Multi-Cluster Management tools with the MCP server.
+func RegisterAll(mcps *server.MCPServer) {
+	// Register all Multi-Cluster Management tools.
+	RegisterListClusters(mcps)
+	RegisterGetUserID(mcps)
+	RegisterSearchUserIDs(mcps)
+	RegisterGetTopUserIDs(mcps)
+	RegisterAssignUserIDs(mcps)
+	RegisterRemoveUserID(mcps)
+	RegisterGetPendingMappings(mcps)
+}
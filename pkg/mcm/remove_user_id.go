@@ -0,0 +1,43 @@
+package mcm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterRemoveUserID registers the remove_user_id tool with the MCP server.
+func RegisterRemoveUserID(mcps *server.MCPServer) {
+	removeUserIDTool := mcp.NewTool(
+		"mcm_remove_user_id",
+		mcp.WithDescription("Remove a userID and its records from its cluster"),
+		mcp.WithString(
+			"userID",
+			mcp.Description("The userID to remove"),
+			mcp.Required(),
+		),
+	)
+
+	mcps.AddTool(removeUserIDTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		userID, _ := req.Params.Arguments["userID"].(string)
+		if userID == "" {
+			return nil, fmt.Errorf("userID parameter is required")
+		}
+
+		res, err := client.RemoveUserID(userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove userID: %w", err)
+		}
+
+		return mcputil.JSONToolResult("UserID Removed", res)
+	})
+}
@@ -0,0 +1,63 @@
+package mcm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterSearchUserIDs registers the search_user_ids tool with the MCP server.
+func RegisterSearchUserIDs(mcps *server.MCPServer) {
+	searchUserIDsTool := mcp.NewTool(
+		"mcm_search_user_ids",
+		mcp.WithDescription("Search for userIDs, optionally restricted to a cluster"),
+		mcp.WithString(
+			"query",
+			mcp.Description("Query to search userIDs for"),
+		),
+		mcp.WithString(
+			"clusterName",
+			mcp.Description("Only return the userIDs assigned to this cluster"),
+		),
+		mcp.WithNumber(
+			"page",
+			mcp.Description("Page of search results to retrieve"),
+		),
+		mcp.WithNumber(
+			"hitsPerPage",
+			mcp.Description("Number of hits per page"),
+		),
+	)
+
+	mcps.AddTool(searchUserIDsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		query, _ := req.Params.Arguments["query"].(string)
+
+		opts := []any{}
+		if clusterName, ok := req.Params.Arguments["clusterName"].(string); ok && clusterName != "" {
+			opts = append(opts, opt.Cluster(clusterName))
+		}
+		if page, ok := req.Params.Arguments["page"].(float64); ok {
+			opts = append(opts, opt.Page(int(page)))
+		}
+		if hitsPerPage, ok := req.Params.Arguments["hitsPerPage"].(float64); ok {
+			opts = append(opts, opt.HitsPerPage(int(hitsPerPage)))
+		}
+
+		res, err := client.SearchUserIDs(query, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search userIDs: %w", err)
+		}
+
+		return mcputil.JSONToolResult("UserIDs", res)
+	})
+}
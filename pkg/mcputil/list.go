@@ -0,0 +1,15 @@
+package mcputil
+
+import "strings"
+
+// SplitList splits a comma-separated tool argument, trimming spaces and
+// dropping empty items.
+func SplitList(s string) []string {
+	var list []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -0,0 +1,30 @@
+package mcputil
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Progress sends a progress notification for a tool call, when the client
+// asked for them by passing a progress token. Failures to notify are ignored,
+// as progress is only informative.
+func Progress(ctx context.Context, req mcp.CallToolRequest, progress, total float64, message string) {
+	if req.Params.Meta == nil || req.Params.Meta.ProgressToken == nil {
+		return
+	}
+	s := server.ServerFromContext(ctx)
+	if s == nil {
+		return
+	}
+	params := map[string]any{
+		"progressToken": req.Params.Meta.ProgressToken,
+		"progress":      progress,
+		"message":       message,
+	}
+	if total > 0 {
+		params["total"] = total
+	}
+	_ = s.SendNotificationToClient(ctx, "notifications/progress", params)
+}
@@ -6,18 +6,20 @@ import (
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
-func RegisterClear(mcps *server.MCPServer, index *search.Index) {
+func RegisterClear(mcps *server.MCPServer, client *search.Client, index *search.Index) {
 	clearIndexTool := mcp.NewTool(
 		"clear_index",
 		mcp.WithDescription("Clear an index by removing all records"),
+		searchutil.WithIndexName(),
 	)
 
-	mcps.AddTool(clearIndexTool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		res, err := index.ClearObjects()
+	mcps.AddTool(clearIndexTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		res, err := searchutil.Index(client, index, req).ClearObjects()
 		if err != nil {
 			return mcp.NewToolResultError(
 				fmt.Sprintf("could not clear index: %v", err),
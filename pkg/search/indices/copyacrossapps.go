@@ -0,0 +1,416 @@
+package indices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+)
+
+// copyBatchSize is the number of records sent per batch to the target app.
+const copyBatchSize = 1000
+
+// CrossAppCopy is the outcome of a copy between applications.
+type CrossAppCopy struct {
+	Source    string   `json:"source"`
+	Target    string   `json:"target"`
+	TargetApp string   `json:"targetAppID"`
+	TmpIndex  string   `json:"tmpIndexName"`
+	Records   int      `json:"records"`
+	Rules     int      `json:"rules"`
+	Synonyms  int      `json:"synonyms"`
+	Replicas  []string `json:"replicas,omitempty"`
+}
+
+// app is an application a tool operates on.
+type app struct {
+	appID  string
+	client *search.Client
+}
+
+func RegisterCopyAcrossApps(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+	copyAcrossAppsTool := mcp.NewTool(
+		"copy_index_across_apps",
+		mcp.WithDescription("Copy the records, settings, rules, synonyms and replicas of an index to another application, e.g. to clone production into a sandbox. The target index is replaced atomically once the copy is complete"),
+		mcp.WithString(
+			"sourceIndexName",
+			mcp.Description("The index to copy (defaults to the configured index)"),
+		),
+		mcp.WithString(
+			"indexName",
+			mcp.Description("The name of the index in the target application (defaults to the source index name)"),
+		),
+		mcp.WithString(
+			"targetProfile",
+			mcp.Description("Named profile of the target application, declared in MCP_PROFILES_FILE"),
+		),
+		mcp.WithString(
+			"targetAppID",
+			mcp.Description("Application ID of the target application, when no targetProfile is given"),
+		),
+		mcp.WithString(
+			"targetAPIKey",
+			mcp.Description("Admin API key for targetAppID"),
+		),
+		mcp.WithString(
+			"sourceProfile",
+			mcp.Description("Named profile of the source application (defaults to the configured application)"),
+		),
+		mcp.WithBoolean(
+			"includeReplicas",
+			mcp.Description("Recreate the replicas of the source index, with their settings, rules and synonyms, in the target application, next to the replicas the target index already has. Replicas are renamed after indexName when it differs from the source (default: true)"),
+		),
+	)
+
+	mcps.AddTool(copyAcrossAppsTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.Params.Arguments
+		includeReplicas := true
+		if v, ok := args["includeReplicas"].(bool); ok {
+			includeReplicas = v
+		}
+
+		src, err := resolveApp(args, "sourceProfile", "", "", app{appID: os.Getenv("ALGOLIA_APP_ID"), client: client})
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		dst, err := resolveApp(args, "targetProfile", "targetAppID", "targetAPIKey", app{})
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if dst.client == nil {
+			return mcp.NewToolResultError("either targetProfile or targetAppID and targetAPIKey are required"), nil
+		}
+
+		srcName := index.GetName()
+		if name, ok := args["sourceIndexName"].(string); ok && name != "" {
+			srcName = name
+		}
+		dstName := srcName
+		if name, ok := args["indexName"].(string); ok && name != "" {
+			dstName = name
+		}
+		if src.appID == dst.appID {
+			return mcp.NewToolResultError("source and target are in the same application, use copy_index instead"), nil
+		}
+
+		res := CrossAppCopy{
+			Source:    srcName,
+			Target:    dstName,
+			TargetApp: dst.appID,
+			TmpIndex:  fmt.Sprintf("%s_tmp_copy_%d", dstName, time.Now().Unix()),
+		}
+		progress := func(message string) {
+			mcputil.Progress(ctx, req, float64(res.Records), 0, message)
+		}
+
+		settings, err := searchutil.GetRawSettings(src.client, srcName)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not get settings of %s: %v", srcName, err)), nil
+		}
+		var replicas []replicaCopy
+		var existing []string
+		if includeReplicas {
+			// Replicas are checked before anything is written, so that a name
+			// conflict doesn't leave the target half copied.
+			replicas, existing, err = planReplicas(dst.client, srcName, dstName, searchutil.GetReplicas(settings))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+		for _, key := range searchutil.UnpromotedSettings {
+			delete(settings, key)
+		}
+
+		// Copy everything into a temporary index, then move it onto the target
+		// so that the target is never left half copied. The temporary index is
+		// deleted if the copy fails before the move.
+		moved := false
+		defer func() {
+			if !moved {
+				_, _ = dst.client.InitIndex(res.TmpIndex).Delete()
+			}
+		}()
+		if err := copyIndexContent(ctx, req, src.client, dst.client, srcName, res.TmpIndex, settings, &res); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		progress(fmt.Sprintf("moving %s to %s", res.TmpIndex, dstName))
+		move, err := dst.client.MoveIndex(res.TmpIndex, dstName)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not move %s to %s: %v", res.TmpIndex, dstName, err)), nil
+		}
+		moved = true
+		if err := move.Wait(); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not wait for the move: %v", err)), nil
+		}
+
+		if len(replicas) > 0 {
+			progress(fmt.Sprintf("recreating %d replicas", len(replicas)))
+			if err := copyReplicas(src.client, dst.client, dstName, existing, replicas); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for _, r := range replicas {
+				res.Replicas = append(res.Replicas, r.target)
+			}
+		}
+
+		return mcputil.JSONToolResult("copy result", res)
+	})
+}
+
+// copyIndexContent copies the settings, rules, synonyms and records of an
+// index into a new index of another application, and waits for every task.
+func copyIndexContent(ctx context.Context, req mcp.CallToolRequest, srcClient, dstClient *search.Client, srcName, dstName string, settings map[string]any, res *CrossAppCopy) error {
+	source := srcClient.InitIndex(srcName)
+	target := dstClient.InitIndex(dstName)
+	var tasks []int64
+
+	t, err := searchutil.SetRawSettings(dstClient, dstName, settings, false)
+	if err != nil {
+		return fmt.Errorf("could not set settings: %w", err)
+	}
+	tasks = append(tasks, t.TaskID)
+
+	rules, err := searchutil.GetAllRules(source)
+	if err != nil {
+		return fmt.Errorf("could not browse rules of %s: %w", srcName, err)
+	}
+	if len(rules) > 0 {
+		r, err := target.SaveRules(sortedValues(rules))
+		if err != nil {
+			return fmt.Errorf("could not save rules: %w", err)
+		}
+		tasks = append(tasks, r.TaskID)
+	}
+	res.Rules = len(rules)
+
+	synonyms, err := searchutil.GetAllSynonyms(source)
+	if err != nil {
+		return fmt.Errorf("could not browse synonyms of %s: %w", srcName, err)
+	}
+	if len(synonyms) > 0 {
+		s, err := target.SaveSynonyms(sortedValues(synonyms))
+		if err != nil {
+			return fmt.Errorf("could not save synonyms: %w", err)
+		}
+		tasks = append(tasks, s.TaskID)
+	}
+	res.Synonyms = len(synonyms)
+
+	// The number of records is only used to report progress.
+	var total float64
+	if count, err := source.Search("", opt.HitsPerPage(0)); err == nil {
+		total = float64(count.NbHits)
+	}
+
+	it, err := source.BrowseObjects()
+	if err != nil {
+		return fmt.Errorf("could not browse records of %s: %w", srcName, err)
+	}
+	var batches []search.GroupBatchRes
+	records := make([]any, 0, copyBatchSize)
+	flush := func() error {
+		if len(records) == 0 {
+			return nil
+		}
+		b, err := target.SaveObjects(records)
+		if err != nil {
+			return fmt.Errorf("could not save records: %w", err)
+		}
+		batches = append(batches, b)
+		res.Records += len(records)
+		records = records[:0]
+		mcputil.Progress(ctx, req, float64(res.Records), total, fmt.Sprintf("copied %d records", res.Records))
+		return nil
+	}
+	for {
+		record, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("could not browse records of %s: %w", srcName, err)
+		}
+		records = append(records, record)
+		if len(records) == copyBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	for _, id := range tasks {
+		if err := target.WaitTask(id); err != nil {
+			return fmt.Errorf("could not wait for task %d: %w", id, err)
+		}
+	}
+	for _, b := range batches {
+		if err := b.Wait(); err != nil {
+			return fmt.Errorf("could not wait for the record batches: %w", err)
+		}
+	}
+	return nil
+}
+
+// replicaCopy is a replica of the source index and the replica recreating it
+// in the target application.
+type replicaCopy struct {
+	source string
+	target string
+	// entry is the target replica as declared on the target primary.
+	entry string
+}
+
+// planReplicas names the replicas to create in the target application after
+// the target index, and returns them with the replicas the target primary
+// already has. It fails when a name is already used by an index of the target
+// application that isn't a replica of the target primary.
+func planReplicas(dstClient *search.Client, srcName, dstName string, entries []string) ([]replicaCopy, []string, error) {
+	if len(entries) == 0 {
+		return nil, nil, nil
+	}
+	var existing []string
+	exists, err := dstClient.InitIndex(dstName).Exists()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not check %s in the target application: %w", dstName, err)
+	}
+	if exists {
+		settings, err := searchutil.GetRawSettings(dstClient, dstName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not get settings of %s in the target application: %w", dstName, err)
+		}
+		existing = searchutil.GetReplicas(settings)
+	}
+
+	var replicas []replicaCopy
+	for _, entry := range entries {
+		source := searchutil.ReplicaName(entry)
+		// Replicas named after the source, such as products_price_asc, are
+		// renamed after the target.
+		target := source
+		if dstName != srcName {
+			if strings.HasPrefix(source, srcName) {
+				target = dstName + strings.TrimPrefix(source, srcName)
+			} else {
+				target = dstName + "_" + source
+			}
+		}
+		r := replicaCopy{source: source, target: target, entry: target}
+		if entry != source {
+			r.entry = fmt.Sprintf("virtual(%s)", target)
+		}
+
+		if !slices.ContainsFunc(existing, func(e string) bool { return searchutil.ReplicaName(e) == target }) {
+			exists, err := dstClient.InitIndex(target).Exists()
+			if err != nil {
+				return nil, nil, fmt.Errorf("could not check %s in the target application: %w", target, err)
+			}
+			if exists {
+				return nil, nil, fmt.Errorf("%s already exists in the target application and isn't a replica of %s", target, dstName)
+			}
+		}
+		replicas = append(replicas, r)
+	}
+	return replicas, existing, nil
+}
+
+// copyReplicas declares the replicas on the target primary, next to the ones
+// it already has, which creates them, then copies their settings, rules and
+// synonyms.
+func copyReplicas(srcClient, dstClient *search.Client, primary string, existing []string, replicas []replicaCopy) error {
+	entries := slices.Clone(existing)
+	for _, r := range replicas {
+		if !slices.ContainsFunc(entries, func(e string) bool { return searchutil.ReplicaName(e) == r.target }) {
+			entries = append(entries, r.entry)
+		}
+	}
+	t, err := searchutil.SetRawSettings(dstClient, primary, map[string]any{"replicas": entries}, false)
+	if err != nil {
+		return fmt.Errorf("could not declare the replicas of %s: %w", primary, err)
+	}
+	if err := dstClient.InitIndex(primary).WaitTask(t.TaskID); err != nil {
+		return fmt.Errorf("could not wait for the replicas of %s: %w", primary, err)
+	}
+
+	for _, r := range replicas {
+		name := r.target
+		settings, err := searchutil.GetRawSettings(srcClient, r.source)
+		if err != nil {
+			return fmt.Errorf("could not get settings of replica %s: %w", r.source, err)
+		}
+		for _, key := range searchutil.UnpromotedSettings {
+			delete(settings, key)
+		}
+		t, err := searchutil.SetRawSettings(dstClient, name, settings, false)
+		if err != nil {
+			return fmt.Errorf("could not set settings of replica %s: %w", name, err)
+		}
+		tasks := []int64{t.TaskID}
+
+		source := srcClient.InitIndex(r.source)
+		target := dstClient.InitIndex(name)
+		if rules, err := searchutil.GetAllRules(source); err != nil {
+			return fmt.Errorf("could not browse rules of replica %s: %w", r.source, err)
+		} else if len(rules) > 0 {
+			r, err := target.SaveRules(sortedValues(rules), opt.ClearExistingRules(true))
+			if err != nil {
+				return fmt.Errorf("could not save rules of replica %s: %w", name, err)
+			}
+			tasks = append(tasks, r.TaskID)
+		}
+		if synonyms, err := searchutil.GetAllSynonyms(source); err != nil {
+			return fmt.Errorf("could not browse synonyms of replica %s: %w", r.source, err)
+		} else if len(synonyms) > 0 {
+			s, err := target.SaveSynonyms(sortedValues(synonyms), opt.ReplaceExistingSynonyms(true))
+			if err != nil {
+				return fmt.Errorf("could not save synonyms of replica %s: %w", name, err)
+			}
+			tasks = append(tasks, s.TaskID)
+		}
+
+		for _, id := range tasks {
+			if err := target.WaitTask(id); err != nil {
+				return fmt.Errorf("could not wait for task %d: %w", id, err)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveApp returns the application named by a profile argument, or by
+// appID and API key arguments, or def when none is given.
+func resolveApp(args map[string]any, profileKey, appIDKey, apiKeyKey string, def app) (app, error) {
+	if name, ok := args[profileKey].(string); ok && name != "" {
+		p, err := searchutil.LoadProfile(name)
+		if err != nil {
+			return app{}, err
+		}
+		return app{appID: p.AppID, client: p.Client()}, nil
+	}
+	if appIDKey == "" {
+		return def, nil
+	}
+	c, err := appClient(def.client, args, appIDKey, apiKeyKey)
+	if err != nil {
+		return app{}, err
+	}
+	if c == def.client {
+		return def, nil
+	}
+	appID, _ := args[appIDKey].(string)
+	return app{appID: appID, client: c}, nil
+}
@@ -0,0 +1,116 @@
+package indices
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+)
+
+func RegisterCreateReplica(mcps *server.MCPServer, writeClient *search.Client, writeIndex *search.Index) {
+	createReplicaTool := mcp.NewTool(
+		"create_replica",
+		mcp.WithDescription("Create a replica of an index, e.g. to sort results differently, and configure its ranking"),
+		mcp.WithString(
+			"replicaName",
+			mcp.Description("The name of the replica index to create"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean(
+			"virtual",
+			mcp.Description("Create a virtual replica, which shares the primary's records and only supports a custom ranking"),
+		),
+		mcp.WithString(
+			"customRanking",
+			mcp.Description("Comma-separated custom ranking of the replica (e.g., 'desc(price),asc(name)')"),
+		),
+		mcp.WithString(
+			"ranking",
+			mcp.Description("Comma-separated ranking criteria of a standard replica (e.g., 'asc(price),typo,geo,words,filters,proximity,attribute,exact,custom')"),
+		),
+		mcp.WithString(
+			"indexName",
+			mcp.Description("The primary index (defaults to the configured index)"),
+		),
+	)
+
+	mcps.AddTool(createReplicaTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if writeIndex == nil {
+			return mcp.NewToolResultError("write API key not set, cannot create replicas"), nil
+		}
+
+		name, ok := req.Params.Arguments["replicaName"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("invalid replicaName format, expected JSON string"), nil
+		}
+		virtual, _ := req.Params.Arguments["virtual"].(bool)
+		customRankingStr, _ := req.Params.Arguments["customRanking"].(string)
+		rankingStr, _ := req.Params.Arguments["ranking"].(string)
+		customRanking := mcputil.SplitList(customRankingStr)
+		ranking := mcputil.SplitList(rankingStr)
+		if virtual && len(ranking) > 0 {
+			return mcp.NewToolResultError("virtual replicas only support a customRanking, not a ranking"), nil
+		}
+
+		primary := searchutil.Index(writeClient, writeIndex, req).GetName()
+		settings, err := searchutil.GetRawSettings(writeClient, primary)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not get settings of %s: %v", primary, err)), nil
+		}
+
+		entry := name
+		if virtual {
+			entry = fmt.Sprintf("virtual(%s)", name)
+		}
+		replicas := searchutil.GetReplicas(settings)
+		for _, r := range replicas {
+			if searchutil.ReplicaName(r) == name {
+				return mcp.NewToolResultError(fmt.Sprintf("%s is already a replica of %s", name, primary)), nil
+			}
+		}
+		replicas = append(slices.Clip(replicas), entry)
+
+		// Declaring the replica on the primary creates it, with a copy of
+		// the primary settings.
+		res, err := searchutil.SetRawSettings(writeClient, primary, map[string]any{"replicas": replicas}, false)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not add replica to %s: %v", primary, err)), nil
+		}
+		if err := writeClient.InitIndex(primary).WaitTask(res.TaskID); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not wait for replica creation: %v", err)), nil
+		}
+
+		result := map[string]any{
+			"primary":  primary,
+			"replica":  name,
+			"virtual":  virtual,
+			"replicas": replicas,
+		}
+		taskIDs := []int64{res.TaskID}
+
+		replicaSettings := map[string]any{}
+		if len(customRanking) > 0 {
+			replicaSettings["customRanking"] = customRanking
+		}
+		if len(ranking) > 0 {
+			replicaSettings["ranking"] = ranking
+		}
+		if len(replicaSettings) > 0 {
+			res, err := searchutil.SetRawSettings(writeClient, name, replicaSettings, false)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("replica created but could not set its ranking: %v", err)), nil
+			}
+			result["settings"] = replicaSettings
+			taskIDs = append(taskIDs, res.TaskID)
+		}
+		result["taskIDs"] = taskIDs
+
+		return mcputil.JSONToolResult("replica", result)
+	})
+}
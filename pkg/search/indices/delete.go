@@ -6,18 +6,20 @@ import (
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
-func RegisterDelete(mcps *server.MCPServer, index *search.Index) {
+func RegisterDelete(mcps *server.MCPServer, client *search.Client, index *search.Index) {
 	deleteIndexTool := mcp.NewTool(
 		"delete_index",
 		mcp.WithDescription("Delete an index by removing all assets and configurations"),
+		searchutil.WithIndexName(),
 	)
 
-	mcps.AddTool(deleteIndexTool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		res, err := index.Delete()
+	mcps.AddTool(deleteIndexTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		res, err := searchutil.Index(client, index, req).Delete()
 		if err != nil {
 			return mcp.NewToolResultError(
 				fmt.Sprintf("could not delete index: %v", err),
@@ -0,0 +1,80 @@
+package indices
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+)
+
+func RegisterDeleteReplica(mcps *server.MCPServer, writeClient *search.Client, writeIndex *search.Index) {
+	deleteReplicaTool := mcp.NewTool(
+		"delete_replica",
+		mcp.WithDescription("Detach a replica from its primary index and delete it"),
+		mcp.WithString(
+			"replicaName",
+			mcp.Description("The name of the replica index to delete"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"indexName",
+			mcp.Description("The primary index (defaults to the configured index)"),
+		),
+	)
+
+	mcps.AddTool(deleteReplicaTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if writeIndex == nil {
+			return mcp.NewToolResultError("write API key not set, cannot delete replicas"), nil
+		}
+
+		name, ok := req.Params.Arguments["replicaName"].(string)
+		if !ok || name == "" {
+			return mcp.NewToolResultError("invalid replicaName format, expected JSON string"), nil
+		}
+
+		primary := searchutil.Index(writeClient, writeIndex, req).GetName()
+		settings, err := searchutil.GetRawSettings(writeClient, primary)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not get settings of %s: %v", primary, err)), nil
+		}
+
+		found := false
+		replicas := []string{}
+		for _, r := range searchutil.GetReplicas(settings) {
+			if searchutil.ReplicaName(r) == name {
+				found = true
+				continue
+			}
+			replicas = append(replicas, r)
+		}
+		if !found {
+			return mcp.NewToolResultError(fmt.Sprintf("%s is not a replica of %s", name, primary)), nil
+		}
+
+		// A replica can only be deleted once it's detached from its primary.
+		detach, err := searchutil.SetRawSettings(writeClient, primary, map[string]any{"replicas": replicas}, false)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not detach replica from %s: %v", primary, err)), nil
+		}
+		if err := writeClient.InitIndex(primary).WaitTask(detach.TaskID); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not wait for replica detachment: %v", err)), nil
+		}
+
+		res, err := writeClient.InitIndex(name).Delete()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("replica detached but could not be deleted: %v", err)), nil
+		}
+
+		return mcputil.JSONToolResult("replica", map[string]any{
+			"primary":  primary,
+			"replica":  name,
+			"replicas": replicas,
+			"taskIDs":  []int64{detach.TaskID, res.TaskID},
+		})
+	})
+}
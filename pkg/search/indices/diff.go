@@ -0,0 +1,120 @@
+package indices
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+)
+
+// ObjectChange is a rule or synonym that differs between two indices.
+type ObjectChange struct {
+	ObjectID string `json:"objectID"`
+	Change   string `json:"change"`
+	Source   any    `json:"source,omitempty"`
+	Target   any    `json:"target,omitempty"`
+}
+
+// IndexDiff is the structured difference between a source and a target index.
+type IndexDiff struct {
+	Source   string                     `json:"source"`
+	Target   string                     `json:"target"`
+	Settings []searchutil.SettingChange `json:"settings"`
+	Rules    []ObjectChange             `json:"rules,omitempty"`
+	Synonyms []ObjectChange             `json:"synonyms,omitempty"`
+}
+
+// diffObjects compares two sets of rules or synonyms by objectID. The
+// objects are compared on their JSON representation.
+func diffObjects[T any](source, target map[string]T) ([]ObjectChange, error) {
+	changes := []ObjectChange{}
+	for _, id := range searchutil.SortedKeys(source, target) {
+		s, inSource := source[id]
+		t, inTarget := target[id]
+		switch {
+		case inSource && !inTarget:
+			changes = append(changes, ObjectChange{ObjectID: id, Change: searchutil.ChangeAdded, Source: s})
+		case !inSource && inTarget:
+			changes = append(changes, ObjectChange{ObjectID: id, Change: searchutil.ChangeRemoved, Target: t})
+		default:
+			sj, err := json.Marshal(s)
+			if err != nil {
+				return nil, err
+			}
+			tj, err := json.Marshal(t)
+			if err != nil {
+				return nil, err
+			}
+			var sv, tv any
+			_ = json.Unmarshal(sj, &sv)
+			_ = json.Unmarshal(tj, &tv)
+			if !reflect.DeepEqual(sv, tv) {
+				changes = append(changes, ObjectChange{ObjectID: id, Change: searchutil.ChangeChanged, Source: s, Target: t})
+			}
+		}
+	}
+	return changes, nil
+}
+
+// diffIndices computes the diff between two indices, possibly in different
+// applications.
+func diffIndices(srcClient, dstClient *search.Client, src, dst string, withRules, withSynonyms bool) (IndexDiff, error) {
+	d := IndexDiff{Source: src, Target: dst}
+
+	srcSettings, err := searchutil.GetRawSettings(srcClient, src)
+	if err != nil {
+		return d, fmt.Errorf("could not get settings of %s: %w", src, err)
+	}
+	dstSettings, err := searchutil.GetRawSettings(dstClient, dst)
+	if err != nil {
+		return d, fmt.Errorf("could not get settings of %s: %w", dst, err)
+	}
+	d.Settings = searchutil.DiffSettings(srcSettings, dstSettings)
+
+	if withRules {
+		srcRules, err := searchutil.GetAllRules(srcClient.InitIndex(src))
+		if err != nil {
+			return d, fmt.Errorf("could not browse rules of %s: %w", src, err)
+		}
+		dstRules, err := searchutil.GetAllRules(dstClient.InitIndex(dst))
+		if err != nil {
+			return d, fmt.Errorf("could not browse rules of %s: %w", dst, err)
+		}
+		if d.Rules, err = diffObjects(srcRules, dstRules); err != nil {
+			return d, fmt.Errorf("could not diff rules: %w", err)
+		}
+	}
+
+	if withSynonyms {
+		srcSynonyms, err := searchutil.GetAllSynonyms(srcClient.InitIndex(src))
+		if err != nil {
+			return d, fmt.Errorf("could not browse synonyms of %s: %w", src, err)
+		}
+		dstSynonyms, err := searchutil.GetAllSynonyms(dstClient.InitIndex(dst))
+		if err != nil {
+			return d, fmt.Errorf("could not browse synonyms of %s: %w", dst, err)
+		}
+		if d.Synonyms, err = diffObjects(srcSynonyms, dstSynonyms); err != nil {
+			return d, fmt.Errorf("could not diff synonyms: %w", err)
+		}
+	}
+
+	return d, nil
+}
+
+// appClient returns a client for another application when credentials are
+// given in the request arguments, or the default client otherwise.
+func appClient(def *search.Client, args map[string]any, appIDKey, apiKeyKey string) (*search.Client, error) {
+	appID, _ := args[appIDKey].(string)
+	apiKey, _ := args[apiKeyKey].(string)
+	switch {
+	case appID == "" && apiKey == "":
+		return def, nil
+	case appID == "" || apiKey == "":
+		return nil, fmt.Errorf("%s and %s must be set together", appIDKey, apiKeyKey)
+	default:
+		return search.NewClient(appID, apiKey), nil
+	}
+}
@@ -0,0 +1,79 @@
+package indices
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+)
+
+func RegisterDiffSettings(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+	diffSettingsTool := mcp.NewTool(
+		"diff_settings",
+		mcp.WithDescription("Compare the settings, and optionally the rules and synonyms, of two indices, possibly in different applications"),
+		mcp.WithString(
+			"sourceIndexName",
+			mcp.Description("The source index (defaults to the configured index)"),
+		),
+		mcp.WithString(
+			"targetIndexName",
+			mcp.Description("The target index to compare against"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"sourceAppID",
+			mcp.Description("Application ID of the source index, when it is in another application"),
+		),
+		mcp.WithString(
+			"sourceAPIKey",
+			mcp.Description("API key for sourceAppID"),
+		),
+		mcp.WithString(
+			"targetAppID",
+			mcp.Description("Application ID of the target index, when it is in another application"),
+		),
+		mcp.WithString(
+			"targetAPIKey",
+			mcp.Description("API key for targetAppID"),
+		),
+		mcp.WithBoolean(
+			"includeRules",
+			mcp.Description("Also compare rules"),
+		),
+		mcp.WithBoolean(
+			"includeSynonyms",
+			mcp.Description("Also compare synonyms"),
+		),
+	)
+
+	mcps.AddTool(diffSettingsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		src, _ := req.Params.Arguments["sourceIndexName"].(string)
+		if src == "" {
+			src = index.GetName()
+		}
+		dst, ok := req.Params.Arguments["targetIndexName"].(string)
+		if !ok || dst == "" {
+			return mcp.NewToolResultError("invalid targetIndexName format, expected JSON string"), nil
+		}
+		withRules, _ := req.Params.Arguments["includeRules"].(bool)
+		withSynonyms, _ := req.Params.Arguments["includeSynonyms"].(bool)
+
+		srcClient, err := appClient(client, req.Params.Arguments, "sourceAppID", "sourceAPIKey")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		dstClient, err := appClient(client, req.Params.Arguments, "targetAppID", "targetAPIKey")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		diff, err := diffIndices(srcClient, dstClient, src, dst, withRules, withSynonyms)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcputil.JSONToolResult("settings diff", diff)
+	})
+}
@@ -8,16 +8,18 @@ import (
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
 )
 
-func RegisterGetSettings(mcps *server.MCPServer, index *search.Index) {
+func RegisterGetSettings(mcps *server.MCPServer, client *search.Client, index *search.Index) {
 	getSettingsTool := mcp.NewTool(
 		"get_settings",
 		mcp.WithDescription("Get the settings for the Algolia index"),
+		searchutil.WithIndexName(),
 	)
 
-	mcps.AddTool(getSettingsTool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		settings, err := index.GetSettings()
+	mcps.AddTool(getSettingsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		settings, err := searchutil.Index(client, index, req).GetSettings()
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,275 @@
+package indices
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+)
+
+// Flags raised on an index by the inventory.
+const (
+	FlagTemporary       = "temporary"
+	FlagOrphanedReplica = "orphaned_replica"
+	FlagStale           = "stale"
+	FlagEmpty           = "empty"
+	FlagLargest         = "largest"
+)
+
+const (
+	defaultStaleDays = 90
+	defaultTopN      = 10
+)
+
+// temporaryPattern matches the names commonly given to temporary indices,
+// e.g. products_tmp, products_temp_1699999999 or tmp_products.
+var temporaryPattern = regexp.MustCompile(`(?i)(^|[_.-])(tmp|temp)([_.-]|\d|$)`)
+
+// InventoryEntry is an index of the inventory along with its flags.
+type InventoryEntry struct {
+	Name        string    `json:"name"`
+	Entries     int64     `json:"entries"`
+	DataSize    int64     `json:"dataSize"`
+	FileSize    int64     `json:"fileSize"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	DaysSince   int       `json:"daysSinceUpdate"`
+	Primary     string    `json:"primary,omitempty"`
+	Replicas    int       `json:"replicas,omitempty"`
+	PendingTask bool      `json:"pendingTask,omitempty"`
+	Flags       []string  `json:"flags,omitempty"`
+}
+
+// Inventory is the enriched listing of the indices of an application.
+type Inventory struct {
+	Total     int              `json:"total"`
+	Flagged   map[string]int   `json:"flagged"`
+	DataSize  int64            `json:"dataSize"`
+	FileSize  int64            `json:"fileSize"`
+	StaleDays int              `json:"staleDays"`
+	Indices   []InventoryEntry `json:"indices"`
+}
+
+func RegisterInventory(mcps *server.MCPServer, client *search.Client) {
+	inventoryTool := mcp.NewTool(
+		"index_inventory",
+		mcp.WithDescription("List the indices of the application with their size, record count and last update, sorted and flagged as temporary, orphaned replica, stale, empty or among the largest, to spot cleanup candidates"),
+		mcp.WithNumber(
+			"staleDays",
+			mcp.Description(fmt.Sprintf("Number of days without update after which an index is flagged as stale (default: %d)", defaultStaleDays)),
+		),
+		mcp.WithNumber(
+			"top",
+			mcp.Description(fmt.Sprintf("Number of largest indices, by dataSize, to flag (default: %d)", defaultTopN)),
+		),
+		mcp.WithString(
+			"sortBy",
+			mcp.Description("Field to sort the indices by, in descending order except for name (default: dataSize)"),
+			mcp.Enum("name", "entries", "dataSize", "fileSize", "updatedAt"),
+		),
+		mcp.WithBoolean(
+			"flaggedOnly",
+			mcp.Description("Only include the indices with at least one flag"),
+		),
+		mcp.WithString(
+			"format",
+			mcp.Description("Output format of the report (default: json)"),
+			mcp.Enum("json", "csv", "markdown"),
+		),
+	)
+
+	mcps.AddTool(inventoryTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		staleDays := defaultStaleDays
+		if v, ok := req.Params.Arguments["staleDays"].(float64); ok && v > 0 {
+			staleDays = int(v)
+		}
+		top := defaultTopN
+		if v, ok := req.Params.Arguments["top"].(float64); ok && v >= 0 {
+			top = int(v)
+		}
+		sortBy, _ := req.Params.Arguments["sortBy"].(string)
+		flaggedOnly, _ := req.Params.Arguments["flaggedOnly"].(bool)
+		format, _ := req.Params.Arguments["format"].(string)
+
+		res, err := client.ListIndices()
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not list indices: %v", err),
+			), nil
+		}
+
+		inv := buildInventory(res.Items, staleDays, top, time.Now())
+		sortInventory(inv.Indices, sortBy)
+		if flaggedOnly {
+			inv.Indices = slices.DeleteFunc(inv.Indices, func(e InventoryEntry) bool {
+				return len(e.Flags) == 0
+			})
+		}
+
+		switch format {
+		case "csv":
+			text, err := inventoryCSV(inv.Indices)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("could not write CSV: %v", err)), nil
+			}
+			return textResource("index inventory", "text/csv", text), nil
+		case "markdown":
+			return textResource("index inventory", "text/markdown", inventoryMarkdown(inv)), nil
+		default:
+			return mcputil.JSONToolResult("index inventory", inv)
+		}
+	})
+}
+
+// buildInventory flags the indices of a listing.
+func buildInventory(items []search.IndexRes, staleDays, top int, now time.Time) Inventory {
+	inv := Inventory{
+		Total:     len(items),
+		Flagged:   map[string]int{},
+		StaleDays: staleDays,
+		Indices:   make([]InventoryEntry, 0, len(items)),
+	}
+
+	names := make(map[string]bool, len(items))
+	for _, it := range items {
+		names[it.Name] = true
+	}
+
+	largest := make([]search.IndexRes, len(items))
+	copy(largest, items)
+	sort.SliceStable(largest, func(i, j int) bool { return largest[i].DataSize > largest[j].DataSize })
+	isLargest := map[string]bool{}
+	for _, it := range largest[:min(top, len(largest))] {
+		if it.DataSize > 0 {
+			isLargest[it.Name] = true
+		}
+	}
+
+	for _, it := range items {
+		e := InventoryEntry{
+			Name:        it.Name,
+			Entries:     it.Entries,
+			DataSize:    it.DataSize,
+			FileSize:    it.FileSize,
+			UpdatedAt:   it.UpdatedAt,
+			DaysSince:   int(now.Sub(it.UpdatedAt).Hours() / 24),
+			Primary:     it.Primary,
+			Replicas:    len(it.Replicas),
+			PendingTask: it.PendingTask,
+		}
+		if temporaryPattern.MatchString(it.Name) {
+			e.Flags = append(e.Flags, FlagTemporary)
+		}
+		if it.Primary != "" && !names[it.Primary] {
+			e.Flags = append(e.Flags, FlagOrphanedReplica)
+		}
+		if !it.UpdatedAt.IsZero() && e.DaysSince >= staleDays {
+			e.Flags = append(e.Flags, FlagStale)
+		}
+		if it.Entries == 0 {
+			e.Flags = append(e.Flags, FlagEmpty)
+		}
+		if isLargest[it.Name] {
+			e.Flags = append(e.Flags, FlagLargest)
+		}
+
+		for _, f := range e.Flags {
+			inv.Flagged[f]++
+		}
+		inv.DataSize += it.DataSize
+		inv.FileSize += it.FileSize
+		inv.Indices = append(inv.Indices, e)
+	}
+	return inv
+}
+
+func sortInventory(entries []InventoryEntry, sortBy string) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		switch sortBy {
+		case "name":
+			return a.Name < b.Name
+		case "entries":
+			return a.Entries > b.Entries
+		case "fileSize":
+			return a.FileSize > b.FileSize
+		case "updatedAt":
+			return a.UpdatedAt.After(b.UpdatedAt)
+		default:
+			return a.DataSize > b.DataSize
+		}
+	})
+}
+
+var inventoryColumns = []string{"name", "entries", "dataSize", "fileSize", "updatedAt", "daysSinceUpdate", "primary", "replicas", "flags"}
+
+func inventoryRow(e InventoryEntry) []string {
+	updatedAt := ""
+	if !e.UpdatedAt.IsZero() {
+		updatedAt = e.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	return []string{
+		e.Name,
+		strconv.FormatInt(e.Entries, 10),
+		strconv.FormatInt(e.DataSize, 10),
+		strconv.FormatInt(e.FileSize, 10),
+		updatedAt,
+		strconv.Itoa(e.DaysSince),
+		e.Primary,
+		strconv.Itoa(e.Replicas),
+		strings.Join(e.Flags, " "),
+	}
+}
+
+func inventoryCSV(entries []InventoryEntry) (string, error) {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	if err := w.Write(inventoryColumns); err != nil {
+		return "", err
+	}
+	for _, e := range entries {
+		if err := w.Write(inventoryRow(e)); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	return sb.String(), w.Error()
+}
+
+func inventoryMarkdown(inv Inventory) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Index inventory\n\n%d indices, %d bytes of data, %d bytes on disk.\n\n", inv.Total, inv.DataSize, inv.FileSize)
+	for _, f := range []string{FlagTemporary, FlagOrphanedReplica, FlagStale, FlagEmpty, FlagLargest} {
+		fmt.Fprintf(&sb, "- %s: %d\n", f, inv.Flagged[f])
+	}
+	sb.WriteString("\n| " + strings.Join(inventoryColumns, " | ") + " |\n")
+	sb.WriteString("|" + strings.Repeat(" --- |", len(inventoryColumns)) + "\n")
+	for _, e := range inv.Indices {
+		row := inventoryRow(e)
+		for i, cell := range row {
+			row[i] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+		sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+	return sb.String()
+}
+
+func textResource(name, mimeType, text string) *mcp.CallToolResult {
+	return mcp.NewToolResultResource(
+		name,
+		mcp.TextResourceContents{
+			MIMEType: mimeType,
+			Text:     text,
+		},
+	)
+}
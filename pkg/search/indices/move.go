@@ -6,6 +6,7 @@ import (
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -19,6 +20,10 @@ func RegisterMove(mcps *server.MCPServer, client *search.Client, index *search.I
 			mcp.Description("The name of the destination index"),
 			mcp.Required(),
 		),
+		mcp.WithString(
+			"sourceIndexName",
+			mcp.Description("The name of the source index (defaults to the configured index)"),
+		),
 	)
 
 	mcps.AddTool(moveIndexTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -27,7 +32,7 @@ func RegisterMove(mcps *server.MCPServer, client *search.Client, index *search.I
 			return mcp.NewToolResultError("invalid indexName format, expected JSON string"), nil
 		}
 
-		res, err := client.CopyIndex(index.GetName(), dst)
+		res, err := client.MoveIndex(searchutil.IndexFromArg(client, index, req, "sourceIndexName").GetName(), dst)
 		if err != nil {
 			return mcp.NewToolResultError(
 				fmt.Sprintf("could not move index: %v", err),
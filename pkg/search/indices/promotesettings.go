@@ -0,0 +1,200 @@
+package indices
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+)
+
+// PromotionPlan lists the changes a promotion applies to the target index.
+type PromotionPlan struct {
+	Source         string                     `json:"source"`
+	Target         string                     `json:"target"`
+	DryRun         bool                       `json:"dryRun"`
+	Settings       []searchutil.SettingChange `json:"settings"`
+	Skipped        []string                   `json:"skipped,omitempty"`
+	SaveRules      []string                   `json:"saveRules,omitempty"`
+	DeleteRules    []string                   `json:"deleteRules,omitempty"`
+	SaveSynonyms   []string                   `json:"saveSynonyms,omitempty"`
+	DeleteSynonyms []string                   `json:"deleteSynonyms,omitempty"`
+	TaskIDs        []int64                    `json:"taskIDs,omitempty"`
+}
+
+func RegisterPromoteSettings(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+	promoteSettingsTool := mcp.NewTool(
+		"promote_settings",
+		mcp.WithDescription("Apply the settings, and optionally the rules and synonyms, that differ between a source and a target index onto the target, e.g. to promote staging to production"),
+		mcp.WithString(
+			"sourceIndexName",
+			mcp.Description("The source index to promote from (defaults to the configured index)"),
+		),
+		mcp.WithString(
+			"targetIndexName",
+			mcp.Description("The target index to apply the changes to"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"sourceAppID",
+			mcp.Description("Application ID of the source index, when it is in another application"),
+		),
+		mcp.WithString(
+			"sourceAPIKey",
+			mcp.Description("API key for sourceAppID"),
+		),
+		mcp.WithString(
+			"targetAppID",
+			mcp.Description("Application ID of the target index, when it is in another application"),
+		),
+		mcp.WithString(
+			"targetAPIKey",
+			mcp.Description("Admin API key for targetAppID"),
+		),
+		mcp.WithString(
+			"keys",
+			mcp.Description("Comma-separated list of settings to promote. Defaults to every differing setting except replicas, primary and version"),
+		),
+		mcp.WithBoolean(
+			"includeRules",
+			mcp.Description("Also save the source rules that are missing or different on the target"),
+		),
+		mcp.WithBoolean(
+			"includeSynonyms",
+			mcp.Description("Also save the source synonyms that are missing or different on the target"),
+		),
+		mcp.WithBoolean(
+			"deleteMissing",
+			mcp.Description("Delete the target rules and synonyms that don't exist on the source"),
+		),
+		mcp.WithBoolean(
+			"forwardToReplicas",
+			mcp.Description("Whether to forward the changes to the replicas of the target index"),
+		),
+		mcp.WithBoolean(
+			"dryRun",
+			mcp.Description("Only return the changes that would be applied"),
+		),
+	)
+
+	mcps.AddTool(promoteSettingsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		src, _ := req.Params.Arguments["sourceIndexName"].(string)
+		if src == "" {
+			src = index.GetName()
+		}
+		dst, ok := req.Params.Arguments["targetIndexName"].(string)
+		if !ok || dst == "" {
+			return mcp.NewToolResultError("invalid targetIndexName format, expected JSON string"), nil
+		}
+		withRules, _ := req.Params.Arguments["includeRules"].(bool)
+		withSynonyms, _ := req.Params.Arguments["includeSynonyms"].(bool)
+		deleteMissing, _ := req.Params.Arguments["deleteMissing"].(bool)
+		forwardToReplicas, _ := req.Params.Arguments["forwardToReplicas"].(bool)
+		dryRun, _ := req.Params.Arguments["dryRun"].(bool)
+
+		keysStr, _ := req.Params.Arguments["keys"].(string)
+		keys := mcputil.SplitList(keysStr)
+
+		srcClient, err := appClient(client, req.Params.Arguments, "sourceAppID", "sourceAPIKey")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		dstClient, err := appClient(client, req.Params.Arguments, "targetAppID", "targetAPIKey")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		diff, err := diffIndices(srcClient, dstClient, src, dst, withRules, withSynonyms)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		plan := PromotionPlan{Source: src, Target: dst, DryRun: dryRun, Settings: []searchutil.SettingChange{}}
+		settings := map[string]any{}
+		for _, c := range diff.Settings {
+			selected := slices.Contains(keys, c.Key) || (len(keys) == 0 && !slices.Contains(searchutil.UnpromotedSettings, c.Key))
+			if !selected {
+				plan.Skipped = append(plan.Skipped, c.Key)
+				continue
+			}
+			plan.Settings = append(plan.Settings, c)
+			// A nil value resets a setting only defined on the target.
+			settings[c.Key] = c.Source
+		}
+
+		var rules []search.Rule
+		for _, c := range diff.Rules {
+			if c.Change == searchutil.ChangeRemoved {
+				if deleteMissing {
+					plan.DeleteRules = append(plan.DeleteRules, c.ObjectID)
+				}
+				continue
+			}
+			plan.SaveRules = append(plan.SaveRules, c.ObjectID)
+			rules = append(rules, c.Source.(search.Rule))
+		}
+
+		var synonyms []search.Synonym
+		for _, c := range diff.Synonyms {
+			if c.Change == searchutil.ChangeRemoved {
+				if deleteMissing {
+					plan.DeleteSynonyms = append(plan.DeleteSynonyms, c.ObjectID)
+				}
+				continue
+			}
+			plan.SaveSynonyms = append(plan.SaveSynonyms, c.ObjectID)
+			synonyms = append(synonyms, c.Source.(search.Synonym))
+		}
+
+		if dryRun {
+			return mcputil.JSONToolResult("promotion preview", plan)
+		}
+
+		target := dstClient.InitIndex(dst)
+		fwd := opt.ForwardToReplicas(forwardToReplicas)
+
+		if len(settings) > 0 {
+			res, err := searchutil.SetRawSettings(dstClient, dst, settings, forwardToReplicas)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("could not set settings: %v", err)), nil
+			}
+			plan.TaskIDs = append(plan.TaskIDs, res.TaskID)
+		}
+		if len(rules) > 0 {
+			res, err := target.SaveRules(rules, fwd)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("could not save rules: %v", err)), nil
+			}
+			plan.TaskIDs = append(plan.TaskIDs, res.TaskID)
+		}
+		for _, id := range plan.DeleteRules {
+			res, err := target.DeleteRule(id, fwd)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("could not delete rule %s: %v", id, err)), nil
+			}
+			plan.TaskIDs = append(plan.TaskIDs, res.TaskID)
+		}
+		if len(synonyms) > 0 {
+			res, err := target.SaveSynonyms(synonyms, fwd)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("could not save synonyms: %v", err)), nil
+			}
+			plan.TaskIDs = append(plan.TaskIDs, res.TaskID)
+		}
+		for _, id := range plan.DeleteSynonyms {
+			res, err := target.DeleteSynonym(id, fwd)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("could not delete synonym %s: %v", id, err)), nil
+			}
+			plan.TaskIDs = append(plan.TaskIDs, res.TaskID)
+		}
+
+		return mcputil.JSONToolResult("promotion result", plan)
+	})
+}
@@ -0,0 +1,188 @@
+package indices
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+)
+
+// restoreBatchSize is the number of records sent per batch on restore.
+const restoreBatchSize = 1000
+
+// RestoreResult is the outcome of a restore.
+type RestoreResult struct {
+	Path      string           `json:"path"`
+	IndexName string           `json:"indexName"`
+	TmpIndex  string           `json:"tmpIndexName"`
+	Manifest  SnapshotManifest `json:"manifest"`
+	Records   int              `json:"records"`
+	Skipped   []string         `json:"skippedSettings,omitempty"`
+}
+
+func RegisterRestore(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+	restoreTool := mcp.NewTool(
+		"restore_index",
+		mcp.WithDescription("Rebuild an index from a snapshot written by snapshot_index. The snapshot is restored into a temporary index, then moved onto the target index once every task is done"),
+		mcp.WithString(
+			"path",
+			mcp.Description("Path to the snapshot directory or .tar.gz archive"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"indexName",
+			mcp.Description("The index to restore into (defaults to the index the snapshot was taken from)"),
+		),
+	)
+
+	mcps.AddTool(restoreTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if index == nil {
+			return mcp.NewToolResultError("write API key not set, cannot restore an index"), nil
+		}
+		path, _ := req.Params.Arguments["path"].(string)
+		target, _ := req.Params.Arguments["indexName"].(string)
+
+		res := RestoreResult{Path: path}
+		var tmp *search.Index
+		var tasks []int64
+		var batches []search.GroupBatchRes
+		var records []map[string]any
+
+		// A failed restore would leave a partial temporary index behind, so
+		// it's deleted unless it was moved onto the target.
+		moved := false
+		defer func() {
+			if tmp != nil && !moved {
+				_, _ = tmp.Delete()
+			}
+		}()
+
+		flush := func() error {
+			if len(records) == 0 {
+				return nil
+			}
+			b, err := tmp.SaveObjects(records)
+			if err != nil {
+				return fmt.Errorf("could not save records: %w", err)
+			}
+			batches = append(batches, b)
+			res.Records += len(records)
+			records = records[:0]
+			return nil
+		}
+
+		err := readSnapshot(path, func(name string, r io.Reader) error {
+			switch name {
+			case manifestFile:
+				if err := json.NewDecoder(r).Decode(&res.Manifest); err != nil {
+					return fmt.Errorf("invalid manifest: %w", err)
+				}
+				if res.Manifest.Version != snapshotVersion {
+					return fmt.Errorf("unsupported snapshot version %d", res.Manifest.Version)
+				}
+				if target == "" {
+					target = res.Manifest.IndexName
+				}
+				res.IndexName = target
+				res.TmpIndex = fmt.Sprintf("%s_tmp_restore_%d", target, time.Now().Unix())
+				tmp = client.InitIndex(res.TmpIndex)
+
+			case settingsFile:
+				var settings map[string]any
+				if err := json.NewDecoder(r).Decode(&settings); err != nil {
+					return fmt.Errorf("invalid settings: %w", err)
+				}
+				// The topology belongs to the target index, which keeps its own
+				// replicas when the temporary index is moved onto it.
+				for _, key := range searchutil.UnpromotedSettings {
+					if _, ok := settings[key]; ok {
+						delete(settings, key)
+						res.Skipped = append(res.Skipped, key)
+					}
+				}
+				t, err := searchutil.SetRawSettings(client, res.TmpIndex, settings, false)
+				if err != nil {
+					return fmt.Errorf("could not set settings: %w", err)
+				}
+				tasks = append(tasks, t.TaskID)
+
+			case rulesFile, synonymsFile:
+				var objects []json.RawMessage
+				if err := json.NewDecoder(r).Decode(&objects); err != nil {
+					return fmt.Errorf("invalid %s: %w", name, err)
+				}
+				if len(objects) == 0 {
+					return nil
+				}
+				kind := "rules"
+				if name == synonymsFile {
+					kind = "synonyms"
+				}
+				var t search.UpdateTaskRes
+				p := fmt.Sprintf("/1/indexes/%s/%s/batch", url.PathEscape(res.TmpIndex), kind)
+				if err := client.CustomRequest(&t, http.MethodPost, p, objects, call.Write); err != nil {
+					return fmt.Errorf("could not save %s: %w", kind, err)
+				}
+				tasks = append(tasks, t.TaskID)
+
+			case recordsFile:
+				sc := bufio.NewScanner(r)
+				sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+				for sc.Scan() {
+					var record map[string]any
+					if err := json.Unmarshal(sc.Bytes(), &record); err != nil {
+						return fmt.Errorf("invalid record on line %d: %w", res.Records+len(records)+1, err)
+					}
+					records = append(records, record)
+					if len(records) == restoreBatchSize {
+						if err := flush(); err != nil {
+							return err
+						}
+					}
+				}
+				if err := sc.Err(); err != nil {
+					return err
+				}
+				return flush()
+			}
+			return nil
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not restore %s: %v", path, err)), nil
+		}
+
+		for _, id := range tasks {
+			if err := tmp.WaitTask(id); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("could not wait for task %d: %v", id, err)), nil
+			}
+		}
+		for _, b := range batches {
+			if err := b.Wait(); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("could not wait for the record batches: %v", err)), nil
+			}
+		}
+
+		move, err := client.MoveIndex(res.TmpIndex, target)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not move %s to %s: %v", res.TmpIndex, target, err)), nil
+		}
+		moved = true
+		if err := move.Wait(); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not wait for the move: %v", err)), nil
+		}
+
+		return mcputil.JSONToolResult("restore result", res)
+	})
+}
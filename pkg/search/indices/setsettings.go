@@ -2,6 +2,7 @@ package indices
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -9,17 +10,23 @@ import (
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
 )
 
-func RegisterSetSettings(mcps *server.MCPServer, writeIndex *search.Index) {
+func RegisterSetSettings(mcps *server.MCPServer, writeClient *search.Client, writeIndex *search.Index) {
 	setSettingTool := mcp.NewTool(
 		"set_settings",
-		mcp.WithDescription("Change the settings for the Algolia index"),
+		mcp.WithDescription("Change the settings for the Algolia index. Only the provided settings are updated, the others are left untouched"),
 		mcp.WithString(
 			"object",
-			mcp.Description("The object to insert or update as a JSON string"),
+			mcp.Description("The settings to update as a JSON string. Set a key to null to reset it to its default"),
 			mcp.Required(),
 		),
+		mcp.WithBoolean(
+			"forwardToReplicas",
+			mcp.Description("Whether to also apply the changes to the replicas of the index"),
+		),
+		searchutil.WithIndexName(),
 	)
 
 	mcps.AddTool(setSettingTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -32,14 +39,16 @@ func RegisterSetSettings(mcps *server.MCPServer, writeIndex *search.Index) {
 			return mcp.NewToolResultError("invalid object format, expected JSON string"), nil
 		}
 
-		// Parse the JSON string into an object
-		var settings search.Settings
-		if err := settings.UnmarshalJSON([]byte(objStr)); err != nil {
+		// Parse the JSON string into an object, keeping only the provided keys
+		var settings map[string]any
+		if err := json.Unmarshal([]byte(objStr), &settings); err != nil {
 			return nil, fmt.Errorf("could not parse settings: %w", err)
 		}
+		forwardToReplicas, _ := req.Params.Arguments["forwardToReplicas"].(bool)
 
 		// Save the settings to the index
-		res, err := writeIndex.SetSettings(settings)
+		indexName := searchutil.Index(writeClient, writeIndex, req).GetName()
+		res, err := searchutil.SetRawSettings(writeClient, indexName, settings, forwardToReplicas)
 		if err != nil {
 			return nil, fmt.Errorf("could not save object: %w", err)
 		}
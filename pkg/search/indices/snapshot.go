@@ -0,0 +1,320 @@
+package indices
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+)
+
+// snapshotVersion is the version of the snapshot layout, recorded in the
+// manifest so that future layouts can still restore older snapshots.
+const snapshotVersion = 1
+
+// Files of a snapshot, in the order they are written and archived. Records
+// come last so that they can be streamed from a tarball.
+const (
+	manifestFile = "manifest.json"
+	settingsFile = "settings.json"
+	rulesFile    = "rules.json"
+	synonymsFile = "synonyms.json"
+	recordsFile  = "records.jsonl"
+)
+
+var snapshotFiles = []string{manifestFile, settingsFile, rulesFile, synonymsFile, recordsFile}
+
+// SnapshotManifest describes the content of a snapshot.
+type SnapshotManifest struct {
+	Version   int       `json:"version"`
+	IndexName string    `json:"indexName"`
+	CreatedAt time.Time `json:"createdAt"`
+	Records   int       `json:"records"`
+	Rules     int       `json:"rules"`
+	Synonyms  int       `json:"synonyms"`
+}
+
+// SnapshotResult is the outcome of a snapshot.
+type SnapshotResult struct {
+	Path     string           `json:"path"`
+	Manifest SnapshotManifest `json:"manifest"`
+}
+
+func RegisterSnapshot(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+	snapshotTool := mcp.NewTool(
+		"snapshot_index",
+		mcp.WithDescription("Save the records, settings, rules and synonyms of an index to a versioned local directory or tarball, to be restored with restore_index"),
+		mcp.WithString(
+			"dir",
+			mcp.Description("Directory to write the snapshot to (defaults to MCP_SNAPSHOTS_DIR, or ~/.algolia-mcp/snapshots). The snapshot is written under <dir>/<indexName>/<timestamp>, to the millisecond"),
+		),
+		mcp.WithString(
+			"format",
+			mcp.Description("Write the snapshot as a directory or as a .tar.gz archive (default: dir)"),
+			mcp.Enum("dir", "tar.gz"),
+		),
+		searchutil.WithIndexName(),
+	)
+
+	mcps.AddTool(snapshotTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if index == nil {
+			return mcp.NewToolResultError("write API key not set, cannot snapshot an index"), nil
+		}
+		dir, _ := req.Params.Arguments["dir"].(string)
+		format, _ := req.Params.Arguments["format"].(string)
+		idx := searchutil.Index(client, index, req)
+
+		base, err := snapshotsDir(dir)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		m := SnapshotManifest{Version: snapshotVersion, IndexName: idx.GetName(), CreatedAt: time.Now().UTC()}
+		path := filepath.Join(base, filepath.Base(m.IndexName), m.CreatedAt.Format("20060102T150405.000Z"))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not create %s: %v", filepath.Dir(path), err)), nil
+		}
+		// Mkdir fails if the directory exists, so a snapshot taken in the
+		// same millisecond never overwrites another one.
+		if err := os.Mkdir(path, 0o755); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not create %s: %v", path, err)), nil
+		}
+		if err := writeSnapshot(client, idx, path, &m); err != nil {
+			_ = os.RemoveAll(path)
+			return mcp.NewToolResultError(fmt.Sprintf("could not snapshot %s: %v", m.IndexName, err)), nil
+		}
+
+		if format == "tar.gz" {
+			archive := path + ".tar.gz"
+			if err := archiveSnapshot(path, archive); err != nil {
+				_ = os.Remove(archive)
+				return mcp.NewToolResultError(fmt.Sprintf("could not archive the snapshot: %v", err)), nil
+			}
+			if err := os.RemoveAll(path); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("could not remove %s: %v", path, err)), nil
+			}
+			path = archive
+		}
+
+		return mcputil.JSONToolResult("snapshot", SnapshotResult{Path: path, Manifest: m})
+	})
+}
+
+// snapshotsDir returns the directory snapshots are written to.
+func snapshotsDir(dir string) (string, error) {
+	if dir != "" {
+		return dir, nil
+	}
+	if dir = os.Getenv("MCP_SNAPSHOTS_DIR"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("no snapshot directory: set dir or MCP_SNAPSHOTS_DIR")
+	}
+	return filepath.Join(home, ".algolia-mcp", "snapshots"), nil
+}
+
+// writeSnapshot writes every file of a snapshot to path, and fills the
+// counts of the manifest.
+func writeSnapshot(client *search.Client, index *search.Index, path string, m *SnapshotManifest) error {
+	settings, err := searchutil.GetRawSettings(client, index.GetName())
+	if err != nil {
+		return fmt.Errorf("could not get settings: %w", err)
+	}
+	if err := writeJSONFile(filepath.Join(path, settingsFile), settings); err != nil {
+		return err
+	}
+
+	rules, err := searchutil.GetAllRules(index)
+	if err != nil {
+		return fmt.Errorf("could not browse rules: %w", err)
+	}
+	m.Rules = len(rules)
+	if err := writeJSONFile(filepath.Join(path, rulesFile), sortedValues(rules)); err != nil {
+		return err
+	}
+
+	synonyms, err := searchutil.GetAllSynonyms(index)
+	if err != nil {
+		return fmt.Errorf("could not browse synonyms: %w", err)
+	}
+	m.Synonyms = len(synonyms)
+	if err := writeJSONFile(filepath.Join(path, synonymsFile), sortedValues(synonyms)); err != nil {
+		return err
+	}
+
+	if m.Records, err = writeRecords(index, filepath.Join(path, recordsFile)); err != nil {
+		return err
+	}
+
+	return writeJSONFile(filepath.Join(path, manifestFile), m)
+}
+
+// writeRecords browses every record of an index into a JSON Lines file.
+func writeRecords(index *search.Index, path string) (int, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	it, err := index.BrowseObjects()
+	if err != nil {
+		return 0, fmt.Errorf("could not browse records: %w", err)
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	n := 0
+	for {
+		record, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return n, fmt.Errorf("could not browse records: %w", err)
+		}
+		if err := enc.Encode(record); err != nil {
+			return n, err
+		}
+		n++
+	}
+	if err := w.Flush(); err != nil {
+		return n, err
+	}
+	return n, f.Close()
+}
+
+// archiveSnapshot writes the files of a snapshot directory to a tarball.
+func archiveSnapshot(dir, archive string) error {
+	f, err := os.Create(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	for _, name := range snapshotFiles {
+		if err := addToArchive(tw, filepath.Join(dir, name), name); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func addToArchive(tw *tar.Writer, path, name string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	hdr, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = name
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// readSnapshot calls fn for every file of a snapshot directory or tarball,
+// in the order of snapshotFiles.
+func readSnapshot(path string, fn func(name string, r io.Reader) error) error {
+	if !strings.HasSuffix(path, ".tar.gz") && !strings.HasSuffix(path, ".tgz") {
+		for _, name := range snapshotFiles {
+			f, err := os.Open(filepath.Join(path, name))
+			if err != nil {
+				return err
+			}
+			err = fn(name, f)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(gz)
+	next := 0
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if next >= len(snapshotFiles) || hdr.Name != snapshotFiles[next] {
+			return fmt.Errorf("unexpected file %s in snapshot archive", hdr.Name)
+		}
+		if err := fn(hdr.Name, tr); err != nil {
+			return err
+		}
+		next++
+	}
+	if next != len(snapshotFiles) {
+		return fmt.Errorf("snapshot archive is missing %s", snapshotFiles[next])
+	}
+	return nil
+}
+
+func writeJSONFile(path string, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
+func sortedValues[T any](m map[string]T) []T {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	values := make([]T, 0, len(m))
+	for _, k := range keys {
+		values = append(values, m[k])
+	}
+	return values
+}
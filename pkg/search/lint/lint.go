@@ -0,0 +1,100 @@
+// Package lint inspects the settings of an index, along with a sample of its
+// records, and reports findings ranked by severity.
+//
+// Rules are pluggable: a house rule is added by calling Register from an
+// init function in a file of this package, or of any package imported by the
+// server.
+package lint
+
+import (
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/search/records"
+)
+
+// Severities of a finding, from the most to the least severe.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+var severityRank = map[string]int{SeverityError: 0, SeverityWarning: 1, SeverityInfo: 2}
+
+// Finding is an issue reported by a rule.
+type Finding struct {
+	Rule           string `json:"rule"`
+	Severity       string `json:"severity"`
+	Attribute      string `json:"attribute,omitempty"`
+	Message        string `json:"message"`
+	Recommendation string `json:"recommendation,omitempty"`
+}
+
+// Input is what the rules inspect.
+type Input struct {
+	IndexName string
+	Settings  search.Settings
+	Schema    records.Schema
+}
+
+// Rule is a named check of an index's settings and records.
+type Rule struct {
+	Name        string
+	Description string
+	Check       func(in Input) []Finding
+}
+
+var (
+	mu    sync.RWMutex
+	rules []Rule
+)
+
+// Register adds a rule to the rule set. A rule with the same name as an
+// existing one replaces it.
+func Register(r Rule) {
+	mu.Lock()
+	defer mu.Unlock()
+	if i := slices.IndexFunc(rules, func(x Rule) bool { return x.Name == r.Name }); i >= 0 {
+		rules[i] = r
+		return
+	}
+	rules = append(rules, r)
+}
+
+// Rules returns the registered rules.
+func Rules() []Rule {
+	mu.RLock()
+	defer mu.RUnlock()
+	return slices.Clone(rules)
+}
+
+// Lint runs the registered rules, or only those listed in only, minus those
+// in skip, and returns the findings at or above minSeverity, the most severe
+// first.
+func Lint(in Input, only, skip []string, minSeverity string) []Finding {
+	maxRank, ok := severityRank[minSeverity]
+	if !ok {
+		maxRank = severityRank[SeverityInfo]
+	}
+
+	findings := []Finding{}
+	for _, r := range Rules() {
+		if (len(only) > 0 && !slices.Contains(only, r.Name)) || slices.Contains(skip, r.Name) {
+			continue
+		}
+		for _, f := range r.Check(in) {
+			f.Rule = r.Name
+			if severityRank[f.Severity] <= maxRank {
+				findings = append(findings, f)
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return severityRank[findings[i].Severity] < severityRank[findings[j].Severity]
+	})
+	return findings
+}
@@ -0,0 +1,88 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/records"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+)
+
+const defaultSampleSize = 500
+
+// Report is the outcome of a lint run.
+type Report struct {
+	IndexName string         `json:"indexName"`
+	Sampled   int            `json:"sampled"`
+	Counts    map[string]int `json:"counts"`
+	Findings  []Finding      `json:"findings"`
+	Rules     []string       `json:"rules"`
+}
+
+func RegisterLintSettings(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+	lintSettingsTool := mcp.NewTool(
+		"lint_settings",
+		mcp.WithDescription("Inspect the settings of an index, along with a sample of its records, and report findings ranked by severity with actionable recommendations"),
+		mcp.WithNumber(
+			"sampleSize",
+			mcp.Description(fmt.Sprintf("Number of records to sample (default: %d, 0 to only inspect the settings)", defaultSampleSize)),
+		),
+		mcp.WithString(
+			"rules",
+			mcp.Description("Comma-separated list of rules to run (defaults to every rule)"),
+		),
+		mcp.WithString(
+			"skipRules",
+			mcp.Description("Comma-separated list of rules to skip"),
+		),
+		mcp.WithString(
+			"minSeverity",
+			mcp.Description("Minimum severity of the reported findings (default: info)"),
+			mcp.Enum(SeverityError, SeverityWarning, SeverityInfo),
+		),
+		searchutil.WithIndexName(),
+	)
+
+	mcps.AddTool(lintSettingsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		sampleSize := defaultSampleSize
+		if v, ok := req.Params.Arguments["sampleSize"].(float64); ok && v >= 0 {
+			sampleSize = int(v)
+		}
+		rulesStr, _ := req.Params.Arguments["rules"].(string)
+		skipStr, _ := req.Params.Arguments["skipRules"].(string)
+		minSeverity, _ := req.Params.Arguments["minSeverity"].(string)
+		idx := searchutil.Index(client, index, req)
+
+		settings, err := idx.GetSettings()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not get settings: %v", err)), nil
+		}
+
+		in := Input{IndexName: idx.GetName(), Settings: settings, Schema: records.Schema{IndexName: idx.GetName()}}
+		if sampleSize > 0 {
+			if in.Schema, err = records.InferSchema(idx, sampleSize, ""); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+
+		report := Report{
+			IndexName: in.IndexName,
+			Sampled:   in.Schema.Sampled,
+			Counts:    map[string]int{},
+			Findings:  Lint(in, mcputil.SplitList(rulesStr), mcputil.SplitList(skipStr), minSeverity),
+		}
+		for _, f := range report.Findings {
+			report.Counts[f.Severity]++
+		}
+		for _, r := range Rules() {
+			report.Rules = append(report.Rules, r.Name)
+		}
+
+		return mcputil.JSONToolResult(fmt.Sprintf("lint report (%d findings)", len(report.Findings)), report)
+	})
+}
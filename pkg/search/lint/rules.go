@@ -0,0 +1,184 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/algolia/mcp/pkg/search/searchutil"
+)
+
+// Thresholds used by the built-in rules.
+const (
+	// highCardinalityRatio is the share of distinct values among the records
+	// holding an attribute from which a facet is considered an identifier.
+	highCardinalityRatio = 0.9
+	// minFacetSample is the number of records holding an attribute below which
+	// its cardinality is not judged.
+	minFacetSample = 50
+)
+
+// identifierPattern matches the names of attributes that usually hold
+// identifiers or product codes, e.g. sku, product_id or variantSku.
+var identifierPattern = regexp.MustCompile(`(^|[._-])(?i:id|sku|ean|upc|gtin|isbn|mpn|barcode|ref|reference|code|partnumber|part_number)s?$|[a-z](Id|ID|Sku|SKU|Code|Ref)s?$`)
+
+func init() {
+	Register(Rule{
+		Name:        "searchable-attributes-unset",
+		Description: "searchableAttributes is not set, so every attribute is searchable",
+		Check:       checkSearchableUnset,
+	})
+	Register(Rule{
+		Name:        "numeric-searchable-attribute",
+		Description: "a searchable attribute only holds numbers",
+		Check:       checkNumericSearchable,
+	})
+	Register(Rule{
+		Name:        "custom-ranking-missing",
+		Description: "customRanking is not set",
+		Check:       checkCustomRankingMissing,
+	})
+	Register(Rule{
+		Name:        "high-cardinality-facet",
+		Description: "a facet is declared on an attribute with mostly unique values, such as an identifier",
+		Check:       checkHighCardinalityFacet,
+	})
+	Register(Rule{
+		Name:        "distinct-without-attribute",
+		Description: "distinct is enabled without attributeForDistinct",
+		Check:       checkDistinctWithoutAttribute,
+	})
+	Register(Rule{
+		Name:        "typo-tolerance-on-identifiers",
+		Description: "typo tolerance applies to searchable SKU or identifier attributes",
+		Check:       checkTypoToleranceOnIdentifiers,
+	})
+	Register(Rule{
+		Name:        "setting-attribute-missing",
+		Description: "an attribute referenced by the settings is missing from the sampled records",
+		Check:       checkSettingAttributeMissing,
+	})
+}
+
+func checkSearchableUnset(in Input) []Finding {
+	if len(in.Settings.SearchableAttributes.Get()) > 0 {
+		return nil
+	}
+	return []Finding{{
+		Severity:       SeverityWarning,
+		Message:        "searchableAttributes is not set: every attribute, IDs and URLs included, is searchable with the same priority",
+		Recommendation: "set searchableAttributes to the attributes users search on, ordered by importance",
+	}}
+}
+
+func checkNumericSearchable(in Input) []Finding {
+	var findings []Finding
+	for _, name := range searchutil.SearchableAttributeNames(in.Settings) {
+		p := in.Schema.Attribute(name)
+		if p == nil || p.Types["number"] == 0 || p.Types["number"] != p.Count-p.Types["null"] {
+			continue
+		}
+		findings = append(findings, Finding{
+			Severity:       SeverityWarning,
+			Attribute:      name,
+			Message:        fmt.Sprintf("%s is searchable but only holds numbers", name),
+			Recommendation: "remove it from searchableAttributes and filter on it with numeric filters, or index it as a string if users type it",
+		})
+	}
+	return findings
+}
+
+func checkCustomRankingMissing(in Input) []Finding {
+	if len(in.Settings.CustomRanking.Get()) > 0 {
+		return nil
+	}
+	return []Finding{{
+		Severity:       SeverityInfo,
+		Message:        "customRanking is not set: records that tie on textual relevance are returned in an arbitrary order",
+		Recommendation: "add business metrics to customRanking, e.g. desc(popularity) or desc(sales)",
+	}}
+}
+
+func checkHighCardinalityFacet(in Input) []Finding {
+	var findings []Finding
+	for _, entry := range in.Settings.AttributesForFaceting.Get() {
+		if strings.HasPrefix(entry, "filterOnly(") {
+			continue
+		}
+		name := searchutil.UnwrapModifier(entry)
+		p := in.Schema.Attribute(name)
+		if p == nil || p.Count < minFacetSample {
+			continue
+		}
+		if ratio := float64(p.Cardinality) / float64(p.Count); ratio >= highCardinalityRatio {
+			findings = append(findings, Finding{
+				Severity:       SeverityWarning,
+				Attribute:      name,
+				Message:        fmt.Sprintf("%s is faceted but has %d distinct values over %d records", name, p.Cardinality, p.Count),
+				Recommendation: fmt.Sprintf("declare it as filterOnly(%s) if it is only used to filter, which avoids computing facet counts", name),
+			})
+		}
+	}
+	return findings
+}
+
+func checkDistinctWithoutAttribute(in Input) []Finding {
+	enabled, n := in.Settings.Distinct.Get()
+	if (!enabled && n == 0) || in.Settings.AttributeForDistinct.Get() != "" {
+		return nil
+	}
+	return []Finding{{
+		Severity:       SeverityError,
+		Message:        "distinct is enabled but attributeForDistinct is not set, so deduplication has no effect",
+		Recommendation: "set attributeForDistinct to the attribute grouping the variants, or disable distinct",
+	}}
+}
+
+func checkTypoToleranceOnIdentifiers(in Input) []Finding {
+	if enabled, mode := in.Settings.TypoTolerance.Get(); !enabled && mode == "" {
+		return nil
+	}
+	disabled := in.Settings.DisableTypoToleranceOnAttributes.Get()
+
+	var findings []Finding
+	for _, name := range searchutil.SearchableAttributeNames(in.Settings) {
+		if !identifierPattern.MatchString(name) || slices.Contains(disabled, name) {
+			continue
+		}
+		findings = append(findings, Finding{
+			Severity:       SeverityWarning,
+			Attribute:      name,
+			Message:        fmt.Sprintf("%s looks like a SKU or identifier but tolerates typos, so a query for one code can match another", name),
+			Recommendation: fmt.Sprintf("add %s to disableTypoToleranceOnAttributes", name),
+		})
+	}
+	return findings
+}
+
+func checkSettingAttributeMissing(in Input) []Finding {
+	if in.Schema.Sampled == 0 {
+		return nil
+	}
+	var findings []Finding
+	check := func(setting string, entries []string) {
+		for _, entry := range entries {
+			name := searchutil.UnwrapModifier(entry)
+			if in.Schema.Attribute(name) != nil {
+				continue
+			}
+			findings = append(findings, Finding{
+				Severity:       SeverityInfo,
+				Attribute:      name,
+				Message:        fmt.Sprintf("%s is listed in %s but missing from the %d sampled records", name, setting, in.Schema.Sampled),
+				Recommendation: "check the attribute name, or remove it from the settings",
+			})
+		}
+	}
+	check("attributesForFaceting", in.Settings.AttributesForFaceting.Get())
+	check("customRanking", in.Settings.CustomRanking.Get())
+	if attr := in.Settings.AttributeForDistinct.Get(); attr != "" {
+		check("attributeForDistinct", []string{attr})
+	}
+	return findings
+}
@@ -0,0 +1,211 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+)
+
+// slowestCount is the number of slowest calls listed in a summary.
+const slowestCount = 10
+
+// Entry is a logged API call.
+type Entry struct {
+	Timestamp        time.Time `json:"timestamp"`
+	Method           string    `json:"method"`
+	URL              string    `json:"url"`
+	Index            string    `json:"index,omitempty"`
+	AnswerCode       int       `json:"answerCode"`
+	ProcessingTimeMS int64     `json:"processingTimeMS"`
+	NbHits           int       `json:"nbHits,omitempty"`
+	NbAPICalls       int       `json:"nbAPICalls,omitempty"`
+	IP               string    `json:"ip,omitempty"`
+	QueryBody        string    `json:"queryBody,omitempty"`
+	Answer           string    `json:"answer,omitempty"`
+}
+
+// ErrorGroup is a set of failed calls sharing an answer code and message.
+type ErrorGroup struct {
+	AnswerCode int       `json:"answerCode"`
+	Message    string    `json:"message"`
+	Count      int       `json:"count"`
+	Indices    []string  `json:"indices,omitempty"`
+	LastSeen   time.Time `json:"lastSeen"`
+	ExampleURL string    `json:"exampleURL"`
+}
+
+// Summary is an overview of a set of logged calls.
+type Summary struct {
+	Count         int            `json:"count"`
+	From          time.Time      `json:"from"`
+	To            time.Time      `json:"to"`
+	AvgTimeMS     int64          `json:"avgProcessingTimeMS"`
+	ErrorRatePerc float64        `json:"errorRatePercent"`
+	ByAnswerCode  map[int]int    `json:"byAnswerCode"`
+	ByIndex       map[string]int `json:"byIndex,omitempty"`
+	Errors        []ErrorGroup   `json:"errors"`
+	Slowest       []Entry        `json:"slowest"`
+}
+
+func RegisterGetLogs(mcps *server.MCPServer, client *search.Client) {
+	getLogsTool := mcp.NewTool(
+		"get_logs",
+		mcp.WithDescription("Get the latest API calls made to the application, with their parameters, answer codes and processing times, e.g. to find out which requests of an integration fail. Requires an API key with the logs ACL"),
+		mcp.WithString(
+			"type",
+			mcp.Description("Type of calls to retrieve"),
+			mcp.Enum("all", "query", "build", "error"),
+		),
+		mcp.WithString(
+			"indexName",
+			mcp.Description("Only retrieve the calls made to this index"),
+		),
+		mcp.WithNumber(
+			"offset",
+			mcp.Description("Position of the first entry to retrieve (0 is the most recent)"),
+		),
+		mcp.WithNumber(
+			"length",
+			mcp.Description("Number of entries to retrieve (max 1000, defaults to 10)"),
+		),
+		mcp.WithBoolean(
+			"summary",
+			mcp.Description("Return a summary grouping errors by answer code and message and listing the slowest calls, instead of the raw entries"),
+		),
+	)
+
+	mcps.AddTool(getLogsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		opts := []any{}
+		if t, ok := req.Params.Arguments["type"].(string); ok && t != "" {
+			opts = append(opts, opt.Type(t))
+		}
+		if indexName, ok := req.Params.Arguments["indexName"].(string); ok && indexName != "" {
+			opts = append(opts, opt.IndexName(indexName))
+		}
+		if offset, ok := req.Params.Arguments["offset"].(float64); ok {
+			opts = append(opts, opt.Offset(int(offset)))
+		}
+		if length, ok := req.Params.Arguments["length"].(float64); ok {
+			opts = append(opts, opt.Length(int(length)))
+		}
+
+		res, err := client.GetLogs(opts...)
+		if err != nil {
+			return mcp.NewToolResultError(
+				fmt.Sprintf("could not get logs: %v", err),
+			), nil
+		}
+
+		entries := make([]Entry, 0, len(res.Logs))
+		for _, l := range res.Logs {
+			entries = append(entries, Entry{
+				Timestamp:        l.Timestamp,
+				Method:           l.Method,
+				URL:              l.URL,
+				Index:            l.Index,
+				AnswerCode:       l.AnswerCode,
+				ProcessingTimeMS: l.ProcessingTime.Milliseconds(),
+				NbHits:           l.QueryNbHits,
+				NbAPICalls:       l.NbAPICalls,
+				IP:               l.IP,
+				QueryBody:        l.QueryBody,
+				Answer:           l.Answer,
+			})
+		}
+
+		if summary, _ := req.Params.Arguments["summary"].(bool); summary {
+			return mcputil.JSONToolResult("logs summary", Summarize(entries))
+		}
+		return mcputil.JSONToolResult("logs", entries)
+	})
+}
+
+// Summarize groups the errors of a set of entries and lists the slowest ones.
+func Summarize(entries []Entry) Summary {
+	s := Summary{
+		Count:        len(entries),
+		ByAnswerCode: map[int]int{},
+		ByIndex:      map[string]int{},
+		Errors:       []ErrorGroup{},
+	}
+
+	groups := map[string]*ErrorGroup{}
+	var total int64
+	nbErrors := 0
+	for _, e := range entries {
+		if s.From.IsZero() || e.Timestamp.Before(s.From) {
+			s.From = e.Timestamp
+		}
+		if e.Timestamp.After(s.To) {
+			s.To = e.Timestamp
+		}
+		s.ByAnswerCode[e.AnswerCode]++
+		if e.Index != "" {
+			s.ByIndex[e.Index]++
+		}
+		total += e.ProcessingTimeMS
+
+		if e.AnswerCode < 400 {
+			continue
+		}
+		nbErrors++
+		msg := errorMessage(e.Answer)
+		key := fmt.Sprintf("%d|%s", e.AnswerCode, msg)
+		g, ok := groups[key]
+		if !ok {
+			g = &ErrorGroup{AnswerCode: e.AnswerCode, Message: msg, ExampleURL: e.URL}
+			groups[key] = g
+		}
+		g.Count++
+		if e.Timestamp.After(g.LastSeen) {
+			g.LastSeen = e.Timestamp
+		}
+		if e.Index != "" && !slices.Contains(g.Indices, e.Index) {
+			g.Indices = append(g.Indices, e.Index)
+		}
+	}
+
+	for _, g := range groups {
+		s.Errors = append(s.Errors, *g)
+	}
+	sort.Slice(s.Errors, func(i, j int) bool {
+		if s.Errors[i].Count != s.Errors[j].Count {
+			return s.Errors[i].Count > s.Errors[j].Count
+		}
+		return s.Errors[i].LastSeen.After(s.Errors[j].LastSeen)
+	})
+
+	slowest := append([]Entry(nil), entries...)
+	sort.SliceStable(slowest, func(i, j int) bool {
+		return slowest[i].ProcessingTimeMS > slowest[j].ProcessingTimeMS
+	})
+	s.Slowest = slowest[:min(slowestCount, len(slowest))]
+
+	if len(entries) > 0 {
+		s.AvgTimeMS = total / int64(len(entries))
+		s.ErrorRatePerc = float64(nbErrors) * 100 / float64(len(entries))
+	}
+	return s
+}
+
+// errorMessage extracts the message of an error answer, which is a JSON
+// object with a "message" field.
+func errorMessage(answer string) string {
+	var a struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(answer), &a); err == nil && a.Message != "" {
+		return a.Message
+	}
+	return answer
+}
@@ -0,0 +1,292 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/analytics"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+	"github.com/algolia/mcp/pkg/search/synonyms"
+)
+
+// spikeFactor is how much higher than the period rate the no-results rate of
+// a day must be to count as a spike.
+const spikeFactor = 1.5
+
+// Causes of searches without results, from the most to the least specific.
+const (
+	CauseRules        = "rules"
+	CauseTypos        = "typos"
+	CauseTooManyWords = "too_many_words"
+	CauseFilters      = "filters"
+	CauseVocabulary   = "vocabulary"
+	CauseNoContent    = "no_content"
+	CauseResolved     = "resolved"
+)
+
+var causeRank = map[string]int{
+	CauseRules: 0, CauseTypos: 1, CauseTooManyWords: 2, CauseFilters: 3,
+	CauseVocabulary: 4, CauseNoContent: 5, CauseResolved: 6,
+}
+
+// QueryProbe is a search without results rerun with variations.
+type QueryProbe struct {
+	Query        string               `json:"query"`
+	Searches     int                  `json:"searches"`
+	WithFilters  int                  `json:"withFilters"`
+	NbHits       int                  `json:"nbHits"`
+	AppliedRules []string             `json:"appliedRules,omitempty"`
+	WithoutRules int                  `json:"withoutRules"`
+	TypoTolerant int                  `json:"typoTolerant"`
+	LastWords    int                  `json:"removeLastWords"`
+	AllOptional  int                  `json:"allWordsOptional"`
+	Cause        string               `json:"cause"`
+	Synonym      *synonyms.Suggestion `json:"synonym,omitempty"`
+}
+
+// NoResultsCause is a cause of searches without results, with the queries it
+// explains and how to fix it.
+type NoResultsCause struct {
+	Cause       string   `json:"cause"`
+	Description string   `json:"description"`
+	Fix         string   `json:"fix"`
+	Searches    int      `json:"searches"`
+	Queries     []string `json:"queries"`
+}
+
+// NoResultsInvestigation is the outcome of investigate_no_results.
+type NoResultsInvestigation struct {
+	IndexName  string                  `json:"indexName"`
+	Rate       analytics.NoResultsRate `json:"rate"`
+	SpikeDates []string                `json:"spikeDates"`
+	Causes     []NoResultsCause        `json:"causes"`
+	Queries    []QueryProbe            `json:"queries"`
+}
+
+func RegisterInvestigateNoResults(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+	investigateNoResultsTool := mcp.NewTool(
+		"investigate_no_results",
+		mcp.WithDescription("Investigate searches without results: fetches the no-results rate trend and the top searches without results, reruns each search with rules disabled, with typo tolerance forced and with removeWordsIfNoResults, and returns the causes ranked by number of searches, with suggested fixes"),
+		mcp.WithString(
+			"startDate",
+			mcp.Description("Start date of the period to analyze, in YYYY-MM-DD format (default: 8 days ago)"),
+		),
+		mcp.WithString(
+			"endDate",
+			mcp.Description("End date of the period to analyze, in YYYY-MM-DD format (default: today)"),
+		),
+		mcp.WithNumber(
+			"limit",
+			mcp.Description("Number of searches without results to investigate (default: 20, max 100)"),
+		),
+		mcp.WithNumber(
+			"sampleSize",
+			mcp.Description("Number of records sampled to look for synonyms of searches that match nothing (default: 1000)"),
+		),
+		searchutil.WithIndexName(),
+	)
+
+	mcps.AddTool(investigateNoResultsTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		startDate, _ := req.Params.Arguments["startDate"].(string)
+		endDate, _ := req.Params.Arguments["endDate"].(string)
+		limit := 20
+		if v, ok := req.Params.Arguments["limit"].(float64); ok && v > 0 {
+			limit = min(int(v), 100)
+		}
+		sampleSize := 1000
+		if v, ok := req.Params.Arguments["sampleSize"].(float64); ok && v > 0 {
+			sampleSize = int(v)
+		}
+		idx := searchutil.Index(client, index, req)
+
+		inv := NoResultsInvestigation{IndexName: idx.GetName(), SpikeDates: []string{}, Causes: []NoResultsCause{}, Queries: []QueryProbe{}}
+		rate, err := analytics.GetNoResultsRate(idx.GetName(), startDate, endDate, "")
+		if err != nil {
+			return nil, fmt.Errorf("could not get the no-results rate: %w", err)
+		}
+		inv.Rate = rate
+		for _, d := range rate.Dates {
+			if rate.Rate > 0 && d.Rate > rate.Rate*spikeFactor {
+				inv.SpikeDates = append(inv.SpikeDates, d.Date)
+			}
+		}
+
+		searches, err := analytics.GetNoResultSearches(idx.GetName(), startDate, endDate, limit)
+		if err != nil {
+			return nil, fmt.Errorf("could not get searches without results: %w", err)
+		}
+		settings, err := idx.GetSettings()
+		if err != nil {
+			return nil, fmt.Errorf("could not get settings: %w", err)
+		}
+
+		var suggester *synonyms.Suggester
+		for i, s := range searches {
+			mcputil.Progress(ctx, req, float64(i), float64(len(searches)), fmt.Sprintf("investigating %q", s.Search))
+			p, err := probe(idx, s)
+			if err != nil {
+				return nil, err
+			}
+			if p.Cause == CauseVocabulary {
+				if suggester == nil {
+					if suggester, err = newSuggester(idx, sampleSize); err != nil {
+						return nil, err
+					}
+				}
+				sug := suggester.Suggest(p.Query, p.Searches)
+				if sug.Status == synonyms.StatusSuggested {
+					p.Synonym = &sug
+				} else {
+					p.Cause = CauseNoContent
+				}
+			}
+			inv.Queries = append(inv.Queries, p)
+		}
+		inv.Causes = rankCauses(inv.Queries, settings)
+
+		return mcputil.JSONToolResult("no results investigation", inv)
+	})
+}
+
+// probe reruns a search without results with variations, and finds the most
+// likely cause of the empty results.
+func probe(index *search.Index, s analytics.NoResultSearch) (QueryProbe, error) {
+	p := QueryProbe{Query: s.Search, Searches: s.Count, WithFilters: s.WithFilterCount}
+
+	res, err := index.Search(s.Search, opt.HitsPerPage(0), opt.GetRankingInfo(true))
+	if err != nil {
+		return p, fmt.Errorf("could not search %q: %w", s.Search, err)
+	}
+	p.NbHits = res.NbHits
+	for _, r := range res.AppliedRules {
+		p.AppliedRules = append(p.AppliedRules, r.ObjectID)
+	}
+
+	variations := []struct {
+		nbHits *int
+		opts   []any
+	}{
+		{&p.WithoutRules, []any{opt.EnableRules(false)}},
+		{&p.TypoTolerant, []any{opt.TypoTolerance(true), opt.MinWordSizefor1Typo(3), opt.MinWordSizefor2Typos(6)}},
+		{&p.LastWords, []any{opt.RemoveWordsIfNoResults("lastWords")}},
+		{&p.AllOptional, []any{opt.RemoveWordsIfNoResults("allOptional")}},
+	}
+	for _, v := range variations {
+		res, err := index.Search(s.Search, append(v.opts, opt.HitsPerPage(0))...)
+		if err != nil {
+			return p, fmt.Errorf("could not search %q: %w", s.Search, err)
+		}
+		*v.nbHits = res.NbHits
+	}
+
+	switch {
+	case p.NbHits > 0 && p.WithFilters*2 >= p.Searches:
+		p.Cause = CauseFilters
+	case p.NbHits > 0:
+		p.Cause = CauseResolved
+	case p.WithoutRules > 0:
+		p.Cause = CauseRules
+	case p.TypoTolerant > 0:
+		p.Cause = CauseTypos
+	case p.LastWords > 0 || p.AllOptional > 0:
+		p.Cause = CauseTooManyWords
+	default:
+		p.Cause = CauseVocabulary
+	}
+	return p, nil
+}
+
+func newSuggester(index *search.Index, sampleSize int) (*synonyms.Suggester, error) {
+	vocab, err := synonyms.SampleVocabulary(index, sampleSize)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := searchutil.GetAllObjects(index, "synonyms")
+	if err != nil {
+		return nil, fmt.Errorf("could not get synonyms: %w", err)
+	}
+	return synonyms.NewSuggester(vocab, existing), nil
+}
+
+// rankCauses groups the probed searches by cause, ranked by number of
+// searches, and describes how to fix each cause given the current settings.
+func rankCauses(probes []QueryProbe, settings search.Settings) []NoResultsCause {
+	byCause := map[string]*NoResultsCause{}
+	var rules, synonymIDs []string
+	for _, p := range probes {
+		c, ok := byCause[p.Cause]
+		if !ok {
+			c = &NoResultsCause{Cause: p.Cause}
+			byCause[p.Cause] = c
+		}
+		c.Searches += p.Searches
+		c.Queries = append(c.Queries, p.Query)
+		if p.Cause == CauseRules {
+			rules = appendMissing(rules, p.AppliedRules...)
+		}
+		if p.Synonym != nil {
+			synonymIDs = appendMissing(synonymIDs, fmt.Sprint(p.Synonym.Synonym["objectID"]))
+		}
+	}
+
+	causes := make([]NoResultsCause, 0, len(byCause))
+	for _, c := range byCause {
+		switch c.Cause {
+		case CauseRules:
+			c.Description = "The searches return results once rules are disabled: a rule adds filters or rewrites the query"
+			c.Fix = "Review the filters and query edits of the applied rules"
+			if len(rules) > 0 {
+				c.Fix += ": " + strings.Join(rules, ", ")
+			}
+		case CauseTypos:
+			typo, mode := settings.TypoTolerance.Get()
+			current := fmt.Sprint(typo)
+			if mode != "" {
+				current = mode
+			}
+			c.Description = "The searches only return results with typo tolerance forced on and lower minimum word sizes"
+			c.Fix = fmt.Sprintf("Enable typoTolerance (currently %s) or lower minWordSizefor1Typo (currently %d), and check disableTypoToleranceOnAttributes", current, settings.MinWordSizefor1Typo.Get())
+		case CauseTooManyWords:
+			c.Description = "The searches return results when some of their words are made optional"
+			c.Fix = fmt.Sprintf("Set removeWordsIfNoResults to lastWords or allOptional (currently %q), or add optionalWords", settings.RemoveWordsIfNoResults.Get())
+		case CauseFilters:
+			c.Description = "The searches return results without filters, and most of them were filtered"
+			c.Fix = "Check the filters sent by the front end, and that the matching records have the filtered facet values"
+		case CauseVocabulary:
+			c.Description = "No record contains the words of the searches, but similar words occur in the records"
+			c.Fix = "Review the suggested synonyms, then save each one with save_synonym, passing its objectID and the synonym object as JSON, or all of them with import_synonyms: " + strings.Join(synonymIDs, ", ")
+		case CauseNoContent:
+			c.Description = "No record contains the words of the searches, or anything similar"
+			c.Fix = "Add the missing content, or a rule redirecting these searches"
+		case CauseResolved:
+			c.Description = "The searches return results today without filters: the records or the configuration changed, or the front end sends other parameters"
+			c.Fix = "Check the search parameters sent by the front end, such as ruleContexts and analyticsTags"
+		}
+		causes = append(causes, *c)
+	}
+	sort.Slice(causes, func(i, j int) bool {
+		if causes[i].Searches != causes[j].Searches {
+			return causes[i].Searches > causes[j].Searches
+		}
+		return causeRank[causes[i].Cause] < causeRank[causes[j].Cause]
+	})
+	return causes
+}
+
+func appendMissing(list []string, values ...string) []string {
+	for _, v := range values {
+		if !slices.Contains(list, v) {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
 )
 
 func RegisterRunQuery(mcps *server.MCPServer, client *search.Client, index *search.Index) {
@@ -51,7 +52,6 @@ func RegisterRunQuery(mcps *server.MCPServer, client *search.Client, index *sear
 	)
 
 	mcps.AddTool(runQueryTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		indexName, _ := req.Params.Arguments["indexName"].(string)
 		query, _ := req.Params.Arguments["query"].(string)
 
 		opts := []any{}
@@ -85,13 +85,8 @@ func RegisterRunQuery(mcps *server.MCPServer, client *search.Client, index *sear
 			opts = append(opts, opt.RestrictSearchableAttributes(attrList...))
 		}
 
-		currentIndex := index
-		if indexName != "" {
-			currentIndex = client.InitIndex(indexName)
-		}
-
 		start := time.Now()
-		resp, err := currentIndex.Search(query, opts...)
+		resp, err := searchutil.Index(client, index, req).Search(query, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("could not search: %w", err)
 		}
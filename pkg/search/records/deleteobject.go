@@ -6,11 +6,12 @@ import (
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
-func RegisterDeleteObject(mcps *server.MCPServer, index *search.Index) {
+func RegisterDeleteObject(mcps *server.MCPServer, client *search.Client, index *search.Index) {
 	deleteObjectTool := mcp.NewTool(
 		"delete_object",
 		mcp.WithDescription("Delete an object by its object ID"),
@@ -19,12 +20,13 @@ func RegisterDeleteObject(mcps *server.MCPServer, index *search.Index) {
 			mcp.Description("The object ID to delete"),
 			mcp.Required(),
 		),
+		searchutil.WithIndexName(),
 	)
 
 	mcps.AddTool(deleteObjectTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		objectID, _ := req.Params.Arguments["objectID"].(string)
 
-		res, err := index.DeleteObject(objectID)
+		res, err := searchutil.Index(client, index, req).DeleteObject(objectID)
 		if err != nil {
 			return mcp.NewToolResultError(
 				fmt.Sprintf("could not delete object: %v", err),
@@ -9,9 +9,10 @@ import (
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
 )
 
-func RegisterGetObject(mcps *server.MCPServer, index *search.Index) {
+func RegisterGetObject(mcps *server.MCPServer, client *search.Client, index *search.Index) {
 	getObjectTool := mcp.NewTool(
 		"get_object",
 		mcp.WithDescription("Get an object by its object ID"),
@@ -20,13 +21,14 @@ func RegisterGetObject(mcps *server.MCPServer, index *search.Index) {
 			mcp.Description("The object ID to look up"),
 			mcp.Required(),
 		),
+		searchutil.WithIndexName(),
 	)
 
 	mcps.AddTool(getObjectTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		objectID, _ := req.Params.Arguments["objectID"].(string)
 
 		var x map[string]any
-		if err := index.GetObject(objectID, &x); err != nil {
+		if err := searchutil.Index(client, index, req).GetObject(objectID, &x); err != nil {
 			return mcp.NewToolResultError(
 				fmt.Sprintf("could not get object: %v", err),
 			), nil
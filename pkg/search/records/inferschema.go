@@ -0,0 +1,341 @@
+package records
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+)
+
+const (
+	defaultSampleSize = 1000
+	maxExamples       = 5
+	maxExampleLength  = 80
+	// maxDistinct caps the number of distinct values tracked per attribute,
+	// beyond which the cardinality is reported as a lower bound.
+	maxDistinct = 10000
+	// maxLargeRecords caps the number of large records listed in the report.
+	maxLargeRecords = 20
+)
+
+// Record size limits, in bytes, and the share of a limit from which a record
+// is reported as close to it.
+const (
+	recordSizeLimitBuild = 10 * 1024
+	recordSizeLimitPaid  = 100 * 1024
+	recordSizeWarnRatio  = 0.8
+)
+
+// AttributeProfile describes the values observed at an attribute path.
+// Elements of arrays are profiled under the array path suffixed with "[]".
+type AttributeProfile struct {
+	Path             string         `json:"path"`
+	Types            map[string]int `json:"types"`
+	Count            int            `json:"count"`
+	FillRate         float64        `json:"fillRate"`
+	Cardinality      int            `json:"cardinality"`
+	CardinalityBound bool           `json:"cardinalityIsLowerBound,omitempty"`
+	Examples         []any          `json:"examples,omitempty"`
+	Bytes            int            `json:"bytes"`
+	AvgBytes         float64        `json:"avgBytes"`
+
+	distinct map[string]struct{}
+	seenIn   int
+	nonEmpty int
+}
+
+// LargeRecord is a record close to or above a record size limit.
+type LargeRecord struct {
+	ObjectID string `json:"objectID"`
+	Size     int    `json:"size"`
+	Limit    int    `json:"limit"`
+}
+
+// SettingsCheck cross-references the index settings with the inferred schema.
+type SettingsCheck struct {
+	FacetedMissing       []string `json:"facetedMissing"`
+	SearchableEmpty      []string `json:"searchableEmpty"`
+	CustomRankingMissing []string `json:"customRankingMissing"`
+}
+
+// Schema is the inferred schema of a sample of records.
+type Schema struct {
+	IndexName     string              `json:"indexName"`
+	Sampled       int                 `json:"sampled"`
+	MaxSize       int                 `json:"maxRecordSize"`
+	AvgSize       float64             `json:"avgRecordSize"`
+	LargeRecords  []LargeRecord       `json:"largeRecords,omitempty"`
+	NearBuildCap  int                 `json:"nearBuildPlanLimit"`
+	NearPaidCap   int                 `json:"nearPaidPlanLimit"`
+	Attributes    []*AttributeProfile `json:"attributes"`
+	Settings      *SettingsCheck      `json:"settings,omitempty"`
+	SettingsError string              `json:"settingsError,omitempty"`
+}
+
+func RegisterInferSchema(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+	inferSchemaTool := mcp.NewTool(
+		"infer_schema",
+		mcp.WithDescription("Browse a sample of records and report, for each attribute path (nested and array paths included), the observed types, fill rate, cardinality, example values and size. Also flags records close to the record size limits and lists faceted or searchable attributes that are missing from the records"),
+		mcp.WithNumber(
+			"limit",
+			mcp.Description(fmt.Sprintf("Maximum number of records to sample (default: %d)", defaultSampleSize)),
+		),
+		mcp.WithString(
+			"filters",
+			mcp.Description("Filters restricting the sampled records"),
+		),
+		searchutil.WithIndexName(),
+	)
+
+	mcps.AddTool(inferSchemaTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		limit := defaultSampleSize
+		if v, ok := req.Params.Arguments["limit"].(float64); ok && v > 0 {
+			limit = int(v)
+		}
+		filters, _ := req.Params.Arguments["filters"].(string)
+		idx := searchutil.Index(client, index, req)
+
+		schema, profiles, err := sample(idx, limit, filters)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		settings, err := idx.GetSettings()
+		if err != nil {
+			schema.SettingsError = fmt.Sprintf("could not get settings: %v", err)
+		} else {
+			schema.Settings = checkSettings(settings, profiles)
+		}
+
+		return mcputil.JSONToolResult("schema", schema)
+	})
+}
+
+// InferSchema browses up to limit records of an index, restricted by
+// filters, and profiles their attributes.
+func InferSchema(index *search.Index, limit int, filters string) (Schema, error) {
+	schema, _, err := sample(index, limit, filters)
+	return schema, err
+}
+
+func sample(index *search.Index, limit int, filters string) (Schema, map[string]*AttributeProfile, error) {
+	it, err := index.BrowseObjects(opt.HitsPerPage(min(limit, 1000)), opt.Filters(filters))
+	if err != nil {
+		return Schema{}, nil, fmt.Errorf("could not browse records: %w", err)
+	}
+
+	schema := Schema{IndexName: index.GetName()}
+	profiles := map[string]*AttributeProfile{}
+	totalSize := 0
+	for schema.Sampled < limit {
+		obj, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Schema{}, nil, fmt.Errorf("could not browse records: %w", err)
+		}
+		record, _ := obj.(map[string]any)
+		schema.Sampled++
+
+		b, _ := json.Marshal(record)
+		size := len(b)
+		totalSize += size
+		schema.MaxSize = max(schema.MaxSize, size)
+		checkRecordSize(&schema, record, size)
+
+		for k, v := range record {
+			profileValue(profiles, k, v, schema.Sampled)
+		}
+	}
+
+	if schema.Sampled > 0 {
+		schema.AvgSize = float64(totalSize) / float64(schema.Sampled)
+	}
+	schema.Attributes = finalizeProfiles(profiles, schema.Sampled)
+	return schema, profiles, nil
+}
+
+// Attribute returns the profile of an attribute as named in the settings.
+// For arrays, the profile of the array elements is returned.
+func (s Schema) Attribute(name string) *AttributeProfile {
+	var found *AttributeProfile
+	for _, p := range s.Attributes {
+		switch p.Path {
+		case name + "[]":
+			return p
+		case name:
+			found = p
+		}
+	}
+	return found
+}
+
+func checkRecordSize(schema *Schema, record map[string]any, size int) {
+	limit := 0
+	switch {
+	case float64(size) >= recordSizeWarnRatio*recordSizeLimitPaid:
+		schema.NearPaidCap++
+		limit = recordSizeLimitPaid
+	case float64(size) >= recordSizeWarnRatio*recordSizeLimitBuild:
+		schema.NearBuildCap++
+		limit = recordSizeLimitBuild
+	default:
+		return
+	}
+	if len(schema.LargeRecords) < maxLargeRecords {
+		id, _ := record["objectID"].(string)
+		schema.LargeRecords = append(schema.LargeRecords, LargeRecord{ObjectID: id, Size: size, Limit: limit})
+	}
+}
+
+// profileValue records a value observed at path in the n-th sampled record,
+// then recurses into objects and arrays.
+func profileValue(profiles map[string]*AttributeProfile, path string, v any, n int) {
+	p, ok := profiles[path]
+	if !ok {
+		p = &AttributeProfile{Path: path, Types: map[string]int{}, distinct: map[string]struct{}{}}
+		profiles[path] = p
+	}
+	p.Types[typeName(v)]++
+	if p.seenIn != n {
+		// Count each record once, even for array elements.
+		p.seenIn = n
+		p.Count++
+	}
+	b, _ := json.Marshal(v)
+	p.Bytes += len(b)
+	if !isEmpty(v) {
+		p.nonEmpty++
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			profileValue(profiles, path+"."+k, child, n)
+		}
+	case []any:
+		for _, child := range v {
+			profileValue(profiles, path+"[]", child, n)
+		}
+	default:
+		key := string(b)
+		if _, seen := p.distinct[key]; !seen {
+			if len(p.distinct) < maxDistinct {
+				p.distinct[key] = struct{}{}
+			} else {
+				p.CardinalityBound = true
+			}
+			if len(p.Examples) < maxExamples && v != nil {
+				p.Examples = append(p.Examples, example(v))
+			}
+		}
+	}
+}
+
+func finalizeProfiles(profiles map[string]*AttributeProfile, sampled int) []*AttributeProfile {
+	list := make([]*AttributeProfile, 0, len(profiles))
+	for _, p := range profiles {
+		if sampled > 0 {
+			p.FillRate = float64(p.Count) / float64(sampled)
+		}
+		if p.Count > 0 {
+			p.AvgBytes = float64(p.Bytes) / float64(p.Count)
+		}
+		p.Cardinality = len(p.distinct)
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Path < list[j].Path })
+	return list
+}
+
+// checkSettings lists the attributes referenced by the settings that were
+// not observed, or only observed empty, in the sampled records.
+func checkSettings(settings search.Settings, profiles map[string]*AttributeProfile) *SettingsCheck {
+	// Algolia attribute names don't distinguish array elements.
+	observed := map[string]bool{}
+	nonEmpty := map[string]bool{}
+	for path, p := range profiles {
+		name := strings.ReplaceAll(path, "[]", "")
+		observed[name] = true
+		if p.nonEmpty > 0 {
+			nonEmpty[name] = true
+		}
+	}
+
+	check := &SettingsCheck{FacetedMissing: []string{}, SearchableEmpty: []string{}, CustomRankingMissing: []string{}}
+	if settings.AttributesForFaceting != nil {
+		for _, a := range settings.AttributesForFaceting.Get() {
+			if name := searchutil.UnwrapModifier(a); !observed[name] {
+				check.FacetedMissing = append(check.FacetedMissing, name)
+			}
+		}
+	}
+	for _, name := range searchutil.SearchableAttributeNames(settings) {
+		if !nonEmpty[name] {
+			check.SearchableEmpty = append(check.SearchableEmpty, name)
+		}
+	}
+	if settings.CustomRanking != nil {
+		for _, a := range settings.CustomRanking.Get() {
+			if name := searchutil.UnwrapModifier(a); !observed[name] {
+				check.CustomRankingMissing = append(check.CustomRankingMissing, name)
+			}
+		}
+	}
+	return check
+}
+
+func isEmpty(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64, json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func example(v any) any {
+	if s, ok := v.(string); ok {
+		if r := []rune(s); len(r) > maxExampleLength {
+			return string(r[:maxExampleLength]) + "…"
+		}
+	}
+	return v
+}
@@ -10,9 +10,11 @@ import (
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/recordschema"
+	"github.com/algolia/mcp/pkg/search/searchutil"
 )
 
-func RegisterInsertObject(mcps *server.MCPServer, writeIndex *search.Index) {
+func RegisterInsertObject(mcps *server.MCPServer, writeClient *search.Client, writeIndex *search.Index) {
 	insertObjectTool := mcp.NewTool(
 		"insert_object",
 		mcp.WithDescription("Insert or update an object in the Algolia index"),
@@ -21,6 +23,8 @@ func RegisterInsertObject(mcps *server.MCPServer, writeIndex *search.Index) {
 			mcp.Description("The object to insert or update as a JSON string (must include an objectID field)"),
 			mcp.Required(),
 		),
+		searchutil.WithIndexName(),
+		recordschema.WithSchema(),
 	)
 
 	mcps.AddTool(insertObjectTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -44,8 +48,20 @@ func RegisterInsertObject(mcps *server.MCPServer, writeIndex *search.Index) {
 			return mcp.NewToolResultError("object must include an objectID field"), nil
 		}
 
+		// Validate the object against the schema of the index, if any
+		idx := searchutil.Index(writeClient, writeIndex, req)
+		schema, err := recordschema.ForRequest(req, idx.GetName())
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if schema != nil {
+			if invalid := recordschema.ValidateRecords(schema, []map[string]any{obj}); len(invalid) > 0 {
+				return recordschema.ErrorResult(invalid, 1), nil
+			}
+		}
+
 		// Save the object to the index
-		res, err := writeIndex.SaveObject(obj)
+		res, err := idx.SaveObject(obj)
 		if err != nil {
 			return nil, fmt.Errorf("could not save object: %w", err)
 		}
@@ -10,9 +10,11 @@ import (
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/recordschema"
+	"github.com/algolia/mcp/pkg/search/searchutil"
 )
 
-func RegisterInsertObjects(mcps *server.MCPServer, writeIndex *search.Index) {
+func RegisterInsertObjects(mcps *server.MCPServer, writeClient *search.Client, writeIndex *search.Index) {
 	insertObjectsTool := mcp.NewTool(
 		"insert_objects",
 		mcp.WithDescription("Insert or update multiple objects in the Algolia index"),
@@ -21,6 +23,8 @@ func RegisterInsertObjects(mcps *server.MCPServer, writeIndex *search.Index) {
 			mcp.Description("Array of objects to insert or update as a JSON string (each must include an objectID field)"),
 			mcp.Required(),
 		),
+		searchutil.WithIndexName(),
+		recordschema.WithSchema(),
 	)
 
 	mcps.AddTool(insertObjectsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -46,8 +50,21 @@ func RegisterInsertObjects(mcps *server.MCPServer, writeIndex *search.Index) {
 			}
 		}
 
+		// Validate every object against the schema of the index, if any,
+		// before sending the batch
+		idx := searchutil.Index(writeClient, writeIndex, req)
+		schema, err := recordschema.ForRequest(req, idx.GetName())
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if schema != nil {
+			if invalid := recordschema.ValidateRecords(schema, objects); len(invalid) > 0 {
+				return recordschema.ErrorResult(invalid, len(objects)), nil
+			}
+		}
+
 		// Save the objects to the index
-		res, err := writeIndex.SaveObjects(objects)
+		res, err := idx.SaveObjects(objects)
 		if err != nil {
 			return nil, fmt.Errorf("could not save objects: %w", err)
 		}
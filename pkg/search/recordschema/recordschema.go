@@ -0,0 +1,79 @@
+package recordschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// RecordError lists the validation errors of a record.
+type RecordError struct {
+	Index    int     `json:"index"`
+	ObjectID any     `json:"objectID,omitempty"`
+	Errors   []Error `json:"errors"`
+}
+
+// WithSchema adds the optional schema argument to a tool.
+func WithSchema() mcp.ToolOption {
+	return mcp.WithString(
+		"schema",
+		mcp.Description("A JSON Schema the records must validate against, overriding the schema configured for the index in MCP_RECORD_SCHEMAS_DIR"),
+	)
+}
+
+// ForRequest returns the schema passed in the schema argument of a request,
+// or the one configured for the index. It returns nil when there is none.
+func ForRequest(req mcp.CallToolRequest, indexName string) (*Schema, error) {
+	if s, ok := req.Params.Arguments["schema"].(string); ok && s != "" {
+		return Parse([]byte(s))
+	}
+	return Load(indexName)
+}
+
+// Load returns the schema configured for an index, read from the
+// <indexName>.json file of the MCP_RECORD_SCHEMAS_DIR directory. It returns
+// nil when the directory is not set or has no schema for the index.
+func Load(indexName string) (*Schema, error) {
+	dir := os.Getenv("MCP_RECORD_SCHEMAS_DIR")
+	if dir == "" || indexName == "" {
+		return nil, nil
+	}
+	path := filepath.Join(dir, filepath.Base(indexName)+".json")
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read the schema of %s: %w", indexName, err)
+	}
+	s, err := Parse(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// ValidateRecords validates every record and returns the errors of the
+// invalid ones.
+func ValidateRecords(s *Schema, records []map[string]any) []RecordError {
+	var invalid []RecordError
+	for i, r := range records {
+		if errs := s.Validate(r); len(errs) > 0 {
+			invalid = append(invalid, RecordError{Index: i, ObjectID: r["objectID"], Errors: errs})
+		}
+	}
+	return invalid
+}
+
+// ErrorResult returns a tool error listing the invalid records.
+func ErrorResult(invalid []RecordError, total int) *mcp.CallToolResult {
+	b, _ := json.MarshalIndent(invalid, "", "  ")
+	return mcp.NewToolResultError(fmt.Sprintf(
+		"%d of %d records do not match the schema, no record was sent:\n%s", len(invalid), total, b,
+	))
+}
@@ -0,0 +1,429 @@
+// Package recordschema validates records against a JSON Schema before they
+// are written to an index.
+//
+// The validator supports the subset of JSON Schema used to describe records:
+// type, enum, const, properties, required, additionalProperties,
+// patternProperties, items, minItems, maxItems, uniqueItems, minLength,
+// maxLength, pattern, minimum, maximum, exclusiveMinimum, exclusiveMaximum,
+// multipleOf, allOf, anyOf, oneOf, not and local $ref ("#/definitions/..."
+// or "#/$defs/..."). Other keywords are ignored.
+package recordschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Schema is a parsed JSON Schema.
+type Schema struct {
+	root     any
+	patterns map[string]*regexp.Regexp
+}
+
+// Error is a validation error at a JSON Pointer path of a record.
+type Error struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Parse parses a JSON Schema and compiles its patterns.
+func Parse(b []byte) (*Schema, error) {
+	var root any
+	if err := json.Unmarshal(b, &root); err != nil {
+		return nil, fmt.Errorf("invalid schema JSON: %w", err)
+	}
+	if _, ok := root.(map[string]any); !ok {
+		if _, ok := root.(bool); !ok {
+			return nil, fmt.Errorf("invalid schema: expected an object or a boolean")
+		}
+	}
+	s := &Schema{root: root, patterns: map[string]*regexp.Regexp{}}
+	if err := s.compile(root, map[string]bool{}); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// compile walks the schema and compiles every regular expression once. Only
+// the keywords holding subschemas are walked, since the other ones, such as
+// enum or default, hold data whose "pattern" keys aren't regular expressions.
+// The targets of $ref are walked too, once each, in case they point outside
+// of these keywords.
+func (s *Schema) compile(node any, refs map[string]bool) error {
+	n, ok := node.(map[string]any)
+	if !ok {
+		return nil
+	}
+	if p, ok := n["pattern"].(string); ok {
+		if err := s.compilePattern(p); err != nil {
+			return err
+		}
+	}
+	if pp, ok := n["patternProperties"].(map[string]any); ok {
+		for p := range pp {
+			if err := s.compilePattern(p); err != nil {
+				return err
+			}
+		}
+	}
+
+	subschemas := []any{n["additionalProperties"], n["items"], n["not"]}
+	for _, k := range []string{"items", "allOf", "anyOf", "oneOf"} {
+		if l, ok := n[k].([]any); ok {
+			subschemas = append(subschemas, l...)
+		}
+	}
+	named := []any{n["properties"], n["patternProperties"], n["$defs"], n["definitions"]}
+	if c, ok := n["components"].(map[string]any); ok {
+		named = append(named, c["schemas"])
+	}
+	for _, m := range named {
+		if m, ok := m.(map[string]any); ok {
+			for _, v := range m {
+				subschemas = append(subschemas, v)
+			}
+		}
+	}
+	if ref, ok := n["$ref"].(string); ok && !refs[ref] {
+		refs[ref] = true
+		// Unresolvable references are reported by Validate.
+		if target, err := s.resolve(ref); err == nil {
+			subschemas = append(subschemas, target)
+		}
+	}
+
+	for _, v := range subschemas {
+		if err := s.compile(v, refs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Schema) compilePattern(p string) error {
+	if _, ok := s.patterns[p]; ok {
+		return nil
+	}
+	re, err := regexp.Compile(p)
+	if err != nil {
+		return fmt.Errorf("invalid schema pattern %q: %w", p, err)
+	}
+	s.patterns[p] = re
+	return nil
+}
+
+// Validate returns the errors of a value against the schema, sorted by path.
+func (s *Schema) Validate(v any) []Error {
+	errs := s.validate(s.root, v, "", 0)
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Path < errs[j].Path })
+	return errs
+}
+
+// maxRefDepth bounds the resolution of recursive $ref.
+const maxRefDepth = 32
+
+func (s *Schema) validate(node, v any, path string, depth int) []Error {
+	if b, ok := node.(bool); ok {
+		if !b {
+			return []Error{{Path: path, Message: "no value is allowed here"}}
+		}
+		return nil
+	}
+	n, ok := node.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	if ref, ok := n["$ref"].(string); ok {
+		if depth >= maxRefDepth {
+			return []Error{{Path: path, Message: fmt.Sprintf("$ref %s is nested too deeply", ref)}}
+		}
+		target, err := s.resolve(ref)
+		if err != nil {
+			return []Error{{Path: path, Message: err.Error()}}
+		}
+		return s.validate(target, v, path, depth+1)
+	}
+
+	var errs []Error
+	fail := func(format string, args ...any) {
+		errs = append(errs, Error{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if t, ok := n["type"]; ok && !matchesType(t, v) {
+		fail("expected %s, got %s", typeList(t), typeOf(v))
+		// The other keywords would only report noise on a value of the wrong type.
+		return errs
+	}
+	if enum, ok := n["enum"].([]any); ok && !containsValue(enum, v) {
+		fail("must be one of %s", compact(enum))
+	}
+	if c, ok := n["const"]; ok && !reflect.DeepEqual(c, v) {
+		fail("must be %s", compact(c))
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		errs = append(errs, s.validateObject(n, v, path, depth)...)
+	case []any:
+		errs = append(errs, s.validateArray(n, v, path, depth)...)
+	case string:
+		length := utf8.RuneCountInString(v)
+		if m, ok := number(n["minLength"]); ok && float64(length) < m {
+			fail("must be at least %v characters long", m)
+		}
+		if m, ok := number(n["maxLength"]); ok && float64(length) > m {
+			fail("must be at most %v characters long", m)
+		}
+		if p, ok := n["pattern"].(string); ok && !s.patterns[p].MatchString(v) {
+			fail("must match the pattern %s", p)
+		}
+	case float64:
+		if m, ok := number(n["minimum"]); ok && v < m {
+			fail("must be >= %v", m)
+		}
+		if m, ok := number(n["maximum"]); ok && v > m {
+			fail("must be <= %v", m)
+		}
+		if m, ok := number(n["exclusiveMinimum"]); ok && v <= m {
+			fail("must be > %v", m)
+		}
+		if m, ok := number(n["exclusiveMaximum"]); ok && v >= m {
+			fail("must be < %v", m)
+		}
+		if m, ok := number(n["multipleOf"]); ok && m > 0 {
+			if q := v / m; math.Abs(q-math.Round(q)) > 1e-9 {
+				fail("must be a multiple of %v", m)
+			}
+		}
+	}
+
+	if all, ok := n["allOf"].([]any); ok {
+		for _, sub := range all {
+			errs = append(errs, s.validate(sub, v, path, depth)...)
+		}
+	}
+	if anyOf, ok := n["anyOf"].([]any); ok {
+		matched := false
+		for _, sub := range anyOf {
+			if len(s.validate(sub, v, path, depth)) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			fail("must match at least one of the anyOf schemas")
+		}
+	}
+	if oneOf, ok := n["oneOf"].([]any); ok {
+		matched := 0
+		for _, sub := range oneOf {
+			if len(s.validate(sub, v, path, depth)) == 0 {
+				matched++
+			}
+		}
+		if matched != 1 {
+			fail("must match exactly one of the oneOf schemas, matched %d", matched)
+		}
+	}
+	if not, ok := n["not"]; ok && len(s.validate(not, v, path, depth)) == 0 {
+		fail("must not match the not schema")
+	}
+
+	return errs
+}
+
+func (s *Schema) validateObject(n map[string]any, v map[string]any, path string, depth int) []Error {
+	var errs []Error
+	if required, ok := n["required"].([]any); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, ok := v[name]; !ok {
+				errs = append(errs, Error{Path: join(path, name), Message: "is required"})
+			}
+		}
+	}
+
+	properties, _ := n["properties"].(map[string]any)
+	patternProperties, _ := n["patternProperties"].(map[string]any)
+	additional, hasAdditional := n["additionalProperties"]
+
+	for _, key := range sortedKeys(v) {
+		value := v[key]
+		p := join(path, key)
+		matched := false
+		if sub, ok := properties[key]; ok {
+			matched = true
+			errs = append(errs, s.validate(sub, value, p, depth)...)
+		}
+		for pattern, sub := range patternProperties {
+			if s.patterns[pattern].MatchString(key) {
+				matched = true
+				errs = append(errs, s.validate(sub, value, p, depth)...)
+			}
+		}
+		if !matched && hasAdditional {
+			if b, ok := additional.(bool); ok && !b {
+				errs = append(errs, Error{Path: p, Message: "is not an allowed property"})
+			} else {
+				errs = append(errs, s.validate(additional, value, p, depth)...)
+			}
+		}
+	}
+	return errs
+}
+
+func (s *Schema) validateArray(n map[string]any, v []any, path string, depth int) []Error {
+	var errs []Error
+	if m, ok := number(n["minItems"]); ok && float64(len(v)) < m {
+		errs = append(errs, Error{Path: path, Message: fmt.Sprintf("must have at least %v items", m)})
+	}
+	if m, ok := number(n["maxItems"]); ok && float64(len(v)) > m {
+		errs = append(errs, Error{Path: path, Message: fmt.Sprintf("must have at most %v items", m)})
+	}
+	if unique, _ := n["uniqueItems"].(bool); unique {
+		for i := range v {
+			for j := 0; j < i; j++ {
+				if reflect.DeepEqual(v[i], v[j]) {
+					errs = append(errs, Error{Path: join(path, strconv.Itoa(i)), Message: fmt.Sprintf("duplicates item %d", j)})
+					break
+				}
+			}
+		}
+	}
+	switch items := n["items"].(type) {
+	case map[string]any, bool:
+		for i, item := range v {
+			errs = append(errs, s.validate(items, item, join(path, strconv.Itoa(i)), depth)...)
+		}
+	case []any:
+		// Tuple validation.
+		for i, item := range v {
+			if i < len(items) {
+				errs = append(errs, s.validate(items[i], item, join(path, strconv.Itoa(i)), depth)...)
+			}
+		}
+	}
+	return errs
+}
+
+// resolve returns the schema a local $ref points to.
+func (s *Schema) resolve(ref string) (any, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("unsupported $ref %s: only local references are supported", ref)
+	}
+	node := s.root
+	for _, token := range strings.Split(strings.TrimPrefix(strings.TrimPrefix(ref, "#"), "/"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unresolvable $ref %s", ref)
+		}
+		if node, ok = m[token]; !ok {
+			return nil, fmt.Errorf("unresolvable $ref %s", ref)
+		}
+	}
+	return node, nil
+}
+
+func matchesType(t, v any) bool {
+	switch t := t.(type) {
+	case string:
+		return isType(t, v)
+	case []any:
+		for _, tt := range t {
+			if name, ok := tt.(string); ok && isType(name, v) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+func isType(name string, v any) bool {
+	switch name {
+	case "integer":
+		f, ok := v.(float64)
+		return ok && f == math.Trunc(f)
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	default:
+		return typeOf(v) == name
+	}
+}
+
+func typeOf(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func typeList(t any) string {
+	if list, ok := t.([]any); ok {
+		names := make([]string, 0, len(list))
+		for _, tt := range list {
+			names = append(names, fmt.Sprint(tt))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+func containsValue(list []any, v any) bool {
+	for _, x := range list {
+		if reflect.DeepEqual(x, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func number(v any) (float64, bool) {
+	f, ok := v.(float64)
+	return f, ok
+}
+
+func compact(v any) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// join appends a token to a JSON Pointer.
+func join(path, token string) string {
+	return path + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,214 @@
+package recordschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		value  string
+		paths  []string
+	}{
+		{
+			name:   "type",
+			schema: `{"type": "object", "properties": {"price": {"type": "number"}}}`,
+			value:  `{"price": "12"}`,
+			paths:  []string{"/price"},
+		},
+		{
+			name:   "type list",
+			schema: `{"properties": {"price": {"type": ["number", "null"]}}}`,
+			value:  `{"price": null}`,
+		},
+		{
+			name:   "integer",
+			schema: `{"properties": {"stock": {"type": "integer"}}}`,
+			value:  `{"stock": 1.5}`,
+			paths:  []string{"/stock"},
+		},
+		{
+			name:   "required",
+			schema: `{"type": "object", "required": ["objectID", "name"]}`,
+			value:  `{"objectID": "1"}`,
+			paths:  []string{"/name"},
+		},
+		{
+			name:   "additionalProperties false",
+			schema: `{"properties": {"name": {}}, "additionalProperties": false}`,
+			value:  `{"name": "a", "extra": 1, "other": 2}`,
+			paths:  []string{"/extra", "/other"},
+		},
+		{
+			name:   "additionalProperties schema",
+			schema: `{"properties": {"name": {}}, "additionalProperties": {"type": "string"}}`,
+			value:  `{"name": 1, "color": "red", "size": 2}`,
+			paths:  []string{"/size"},
+		},
+		{
+			name:   "patternProperties",
+			schema: `{"patternProperties": {"^price_": {"type": "number"}}, "additionalProperties": false}`,
+			value:  `{"price_eur": 1, "price_usd": "1", "name": "a"}`,
+			paths:  []string{"/name", "/price_usd"},
+		},
+		{
+			name:   "anyOf matched",
+			schema: `{"properties": {"id": {"anyOf": [{"type": "string"}, {"type": "integer"}]}}}`,
+			value:  `{"id": 3}`,
+		},
+		{
+			name:   "anyOf unmatched",
+			schema: `{"properties": {"id": {"anyOf": [{"type": "string"}, {"type": "integer"}]}}}`,
+			value:  `{"id": true}`,
+			paths:  []string{"/id"},
+		},
+		{
+			name:   "oneOf exactly one",
+			schema: `{"oneOf": [{"required": ["a"]}, {"required": ["b"]}]}`,
+			value:  `{"a": 1}`,
+		},
+		{
+			name:   "oneOf several",
+			schema: `{"oneOf": [{"required": ["a"]}, {"required": ["b"]}]}`,
+			value:  `{"a": 1, "b": 2}`,
+			paths:  []string{""},
+		},
+		{
+			name:   "oneOf none",
+			schema: `{"oneOf": [{"required": ["a"]}, {"required": ["b"]}]}`,
+			value:  `{}`,
+			paths:  []string{""},
+		},
+		{
+			name:   "allOf",
+			schema: `{"allOf": [{"required": ["a"]}, {"required": ["b"]}]}`,
+			value:  `{}`,
+			paths:  []string{"/a", "/b"},
+		},
+		{
+			name:   "not",
+			schema: `{"properties": {"name": {"not": {"const": ""}}}}`,
+			value:  `{"name": ""}`,
+			paths:  []string{"/name"},
+		},
+		{
+			name:   "ref definitions",
+			schema: `{"definitions": {"price": {"type": "number", "minimum": 0}}, "properties": {"price": {"$ref": "#/definitions/price"}}}`,
+			value:  `{"price": -1}`,
+			paths:  []string{"/price"},
+		},
+		{
+			name:   "ref $defs",
+			schema: `{"$defs": {"tag": {"type": "string"}}, "properties": {"tags": {"type": "array", "items": {"$ref": "#/$defs/tag"}}}}`,
+			value:  `{"tags": ["a", 1, "c", 2]}`,
+			paths:  []string{"/tags/1", "/tags/3"},
+		},
+		{
+			name:   "recursive ref",
+			schema: `{"$defs": {"node": {"type": "object", "properties": {"name": {"type": "string"}, "children": {"type": "array", "items": {"$ref": "#/$defs/node"}}}}}, "$ref": "#/$defs/node"}`,
+			value:  `{"name": "a", "children": [{"name": "b", "children": [{"name": 3}]}]}`,
+			paths:  []string{"/children/0/children/0/name"},
+		},
+		{
+			name:   "unresolvable ref",
+			schema: `{"properties": {"a": {"$ref": "#/definitions/missing"}}}`,
+			value:  `{"a": 1}`,
+			paths:  []string{"/a"},
+		},
+		{
+			name:   "enum",
+			schema: `{"properties": {"color": {"enum": ["red", "blue"]}}}`,
+			value:  `{"color": "green"}`,
+			paths:  []string{"/color"},
+		},
+		{
+			name:   "string constraints",
+			schema: `{"properties": {"sku": {"type": "string", "minLength": 3, "maxLength": 5, "pattern": "^[A-Z]+$"}}}`,
+			value:  `{"sku": "ab"}`,
+			paths:  []string{"/sku", "/sku"},
+		},
+		{
+			name:   "minLength counts characters",
+			schema: `{"properties": {"name": {"maxLength": 3}}}`,
+			value:  `{"name": "été"}`,
+		},
+		{
+			name:   "number constraints",
+			schema: `{"properties": {"a": {"exclusiveMinimum": 0}, "b": {"maximum": 10}, "c": {"multipleOf": 0.01}}}`,
+			value:  `{"a": 0, "b": 11, "c": 1.23}`,
+			paths:  []string{"/a", "/b"},
+		},
+		{
+			name:   "array constraints",
+			schema: `{"properties": {"tags": {"type": "array", "minItems": 1, "maxItems": 3, "uniqueItems": true}}}`,
+			value:  `{"tags": ["a", "b", "a", "c"]}`,
+			paths:  []string{"/tags", "/tags/2"},
+		},
+		{
+			name:   "tuple items",
+			schema: `{"properties": {"point": {"items": [{"type": "number"}, {"type": "string"}]}}}`,
+			value:  `{"point": [1, 2, "extra"]}`,
+			paths:  []string{"/point/1"},
+		},
+		{
+			name:   "false schema",
+			schema: `{"properties": {"internal": false}}`,
+			value:  `{"internal": 1}`,
+			paths:  []string{"/internal"},
+		},
+		{
+			name:   "pattern keys in data",
+			schema: `{"properties": {"rule": {"enum": [{"pattern": "("}], "default": {"pattern": "["}}}}`,
+			value:  `{"rule": {"pattern": "("}}`,
+		},
+		{
+			name:   "pattern behind a ref",
+			schema: `{"properties": {"sku": {"$ref": "#/x-shared/sku"}}, "x-shared": {"sku": {"pattern": "^[A-Z]+$"}}}`,
+			value:  `{"sku": "abc"}`,
+			paths:  []string{"/sku"},
+		},
+		{
+			name:   "escaped property names",
+			schema: `{"properties": {"a/b": {"type": "string"}}}`,
+			value:  `{"a/b": 1}`,
+			paths:  []string{"/a~1b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse([]byte(tt.schema))
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			var v any
+			if err := json.Unmarshal([]byte(tt.value), &v); err != nil {
+				t.Fatal(err)
+			}
+			var paths []string
+			for _, e := range s.Validate(v) {
+				paths = append(paths, e.Path)
+			}
+			if !reflect.DeepEqual(paths, tt.paths) {
+				t.Errorf("error paths = %q, want %q (errors: %v)", paths, tt.paths, s.Validate(v))
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, schema := range []string{
+		`not json`,
+		`"a string"`,
+		`{"properties": {"a": {"pattern": "("}}}`,
+		`{"patternProperties": {"[": {}}}`,
+		`{"items": [{"not": {"pattern": "("}}]}`,
+		`{"components": {"schemas": {"a": {"pattern": "("}}}}`,
+	} {
+		if _, err := Parse([]byte(schema)); err == nil {
+			t.Errorf("Parse(%s) succeeded, want an error", schema)
+		}
+	}
+}
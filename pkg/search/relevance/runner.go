@@ -0,0 +1,251 @@
+package relevance
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+)
+
+// defaultHitsPerPage is the number of hits fetched per case when neither
+// the case parameters nor its assertions require more.
+const defaultHitsPerPage = 20
+
+// Report is the outcome of a suite run.
+type Report struct {
+	IndexName        string       `json:"indexName"`
+	CompareIndexName string       `json:"compareIndexName,omitempty"`
+	Passed           int          `json:"passed"`
+	Failed           int          `json:"failed"`
+	Regressions      int          `json:"regressions,omitempty"`
+	Cases            []CaseResult `json:"cases"`
+}
+
+// CaseResult is the outcome of a single case.
+type CaseResult struct {
+	Name       string            `json:"name,omitempty"`
+	Query      string            `json:"query"`
+	IndexName  string            `json:"indexName"`
+	Passed     bool              `json:"passed"`
+	NbHits     int               `json:"nbHits"`
+	Top        []string          `json:"top"`
+	Assertions []AssertionResult `json:"assertions"`
+	Error      string            `json:"error,omitempty"`
+	Compare    *Comparison       `json:"compare,omitempty"`
+}
+
+// AssertionResult is the outcome of a single assertion.
+type AssertionResult struct {
+	Assertion
+	Passed bool   `json:"passed"`
+	Actual any    `json:"actual,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Comparison is the outcome of a case on the compare index, along with the
+// ranking differences against the primary index.
+type Comparison struct {
+	IndexName  string            `json:"indexName"`
+	Passed     bool              `json:"passed"`
+	Regression bool              `json:"regression"`
+	NbHits     int               `json:"nbHits"`
+	Top        []string          `json:"top"`
+	Assertions []AssertionResult `json:"assertions"`
+	Error      string            `json:"error,omitempty"`
+	Diff       RankingDiff       `json:"diff"`
+}
+
+// RankingDiff lists how the top hits differ between two result sets.
+type RankingDiff struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Moved   []Move   `json:"moved,omitempty"`
+}
+
+// Move is an objectID found at different positions (1-based) in two result sets.
+type Move struct {
+	ObjectID string `json:"objectID"`
+	From     int    `json:"from"`
+	To       int    `json:"to"`
+}
+
+// Run executes every case of the suite and evaluates its assertions.
+func Run(client *search.Client, s Suite) Report {
+	report := Report{
+		IndexName:        s.IndexName,
+		CompareIndexName: s.CompareIndexName,
+		Cases:            make([]CaseResult, 0, len(s.Cases)),
+	}
+
+	for _, c := range s.Cases {
+		indexName := s.IndexName
+		if c.IndexName != "" {
+			indexName = c.IndexName
+		}
+		params := mergeParams(s.Params, c.Params, c.Assertions)
+
+		cr := CaseResult{Name: c.Name, Query: c.Query, IndexName: indexName}
+		res, err := client.InitIndex(indexName).Search(c.Query, opt.ExtraOptions(params))
+		if err != nil {
+			cr.Error = fmt.Sprintf("could not search: %v", err)
+		} else {
+			cr.NbHits = res.NbHits
+			cr.Top = objectIDs(res.Hits)
+			cr.Assertions, cr.Passed = evaluate(c.Assertions, cr.NbHits, cr.Top)
+		}
+
+		if s.CompareIndexName != "" {
+			cmp := Comparison{IndexName: s.CompareIndexName}
+			res, err := client.InitIndex(s.CompareIndexName).Search(c.Query, opt.ExtraOptions(params))
+			if err != nil {
+				cmp.Error = fmt.Sprintf("could not search: %v", err)
+			} else {
+				cmp.NbHits = res.NbHits
+				cmp.Top = objectIDs(res.Hits)
+				cmp.Assertions, cmp.Passed = evaluate(c.Assertions, cmp.NbHits, cmp.Top)
+			}
+			// Without the primary results, there is nothing to diff against.
+			if cr.Error == "" && cmp.Error == "" {
+				cmp.Diff = DiffRanking(cr.Top, cmp.Top)
+				cmp.Regression = cr.Passed && !cmp.Passed
+			}
+			if cmp.Regression {
+				report.Regressions++
+			}
+			cr.Compare = &cmp
+		}
+
+		if cr.Passed {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+		report.Cases = append(report.Cases, cr)
+	}
+
+	return report
+}
+
+// mergeParams combines suite and case parameters, and makes sure enough
+// hits are fetched for the assertions to be evaluated.
+func mergeParams(suite, c map[string]any, assertions []Assertion) map[string]any {
+	params := map[string]any{"attributesToRetrieve": []string{"objectID"}}
+	for k, v := range suite {
+		params[k] = v
+	}
+	for k, v := range c {
+		params[k] = v
+	}
+
+	if _, ok := params["hitsPerPage"]; !ok {
+		n := defaultHitsPerPage
+		for _, a := range assertions {
+			n = max(n, a.Position, a.Within, len(a.ObjectIDs))
+		}
+		params["hitsPerPage"] = n
+	}
+	return params
+}
+
+func objectIDs(hits []map[string]any) []string {
+	ids := make([]string, 0, len(hits))
+	for _, h := range hits {
+		id, _ := h["objectID"].(string)
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func evaluate(assertions []Assertion, nbHits int, top []string) ([]AssertionResult, bool) {
+	results := make([]AssertionResult, 0, len(assertions))
+	passed := true
+	for _, a := range assertions {
+		r := check(a, nbHits, top)
+		passed = passed && r.Passed
+		results = append(results, r)
+	}
+	return results, passed
+}
+
+func check(a Assertion, nbHits int, top []string) AssertionResult {
+	r := AssertionResult{Assertion: a}
+	pos := slices.Index(top, a.ObjectID) + 1
+
+	switch a.Type {
+	case AssertPosition:
+		r.Actual = pos
+		r.Passed = pos == a.Position
+		if !r.Passed {
+			r.Detail = fmt.Sprintf("expected %s at position %d, got %s", a.ObjectID, a.Position, positionString(pos))
+		}
+	case AssertPresent:
+		r.Actual = pos
+		r.Passed = pos > 0 && (a.Within == 0 || pos <= a.Within)
+		if !r.Passed {
+			r.Detail = fmt.Sprintf("expected %s within the first %d hits, got %s", a.ObjectID, window(a.Within, top), positionString(pos))
+		}
+	case AssertAbsent:
+		r.Actual = pos
+		r.Passed = pos == 0 || (a.Within > 0 && pos > a.Within)
+		if !r.Passed {
+			r.Detail = fmt.Sprintf("expected %s absent from the first %d hits, got %s", a.ObjectID, window(a.Within, top), positionString(pos))
+		}
+	case AssertNbHits:
+		r.Actual = nbHits
+		r.Passed = (a.Min == nil || nbHits >= *a.Min) && (a.Max == nil || nbHits <= *a.Max)
+		if !r.Passed {
+			r.Detail = fmt.Sprintf("expected nbHits in [%s, %s], got %d", bound(a.Min), bound(a.Max), nbHits)
+		}
+	case AssertTop:
+		got := top[:min(len(a.ObjectIDs), len(top))]
+		r.Actual = got
+		r.Passed = slices.Equal(got, a.ObjectIDs)
+		if !r.Passed {
+			d := DiffRanking(a.ObjectIDs, got)
+			r.Detail = fmt.Sprintf("top %d differs: missing %v, unexpected %v, moved %v", len(a.ObjectIDs), d.Removed, d.Added, d.Moved)
+		}
+	}
+	return r
+}
+
+// DiffRanking compares two ordered lists of objectIDs.
+func DiffRanking(before, after []string) RankingDiff {
+	var d RankingDiff
+	for i, id := range before {
+		j := slices.Index(after, id)
+		switch {
+		case j < 0:
+			d.Removed = append(d.Removed, id)
+		case j != i:
+			d.Moved = append(d.Moved, Move{ObjectID: id, From: i + 1, To: j + 1})
+		}
+	}
+	for _, id := range after {
+		if !slices.Contains(before, id) {
+			d.Added = append(d.Added, id)
+		}
+	}
+	return d
+}
+
+func positionString(pos int) string {
+	if pos == 0 {
+		return "not found"
+	}
+	return fmt.Sprintf("position %d", pos)
+}
+
+func window(within int, top []string) int {
+	if within > 0 {
+		return within
+	}
+	return len(top)
+}
+
+func bound(v *int) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprint(*v)
+}
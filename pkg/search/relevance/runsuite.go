@@ -0,0 +1,87 @@
+package relevance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+)
+
+func RegisterRunSuite(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+	runSuiteTool := mcp.NewTool(
+		"run_relevance_suite",
+		mcp.WithDescription("Run a relevance regression test suite of golden queries and report pass/fail with ranking diffs"),
+		mcp.WithString(
+			"path",
+			mcp.Description("Path to a local YAML or JSON suite file"),
+		),
+		mcp.WithString(
+			"suite",
+			mcp.Description("The suite as a JSON string, used when no path is given. Example: {\"cases\":[{\"name\":\"brand\",\"query\":\"nike\",\"params\":{\"filters\":\"price < 100\"},\"assertions\":[{\"type\":\"position\",\"objectID\":\"123\",\"position\":1},{\"type\":\"present\",\"objectID\":\"456\",\"within\":10},{\"type\":\"absent\",\"objectID\":\"789\"},{\"type\":\"nbHits\",\"min\":10,\"max\":500},{\"type\":\"top\",\"objectIDs\":[\"123\",\"456\"]}]}]}"),
+		),
+		mcp.WithString(
+			"indexName",
+			mcp.Description("The index to run the suite against (overrides the suite's indexName, defaults to the configured index)"),
+		),
+		mcp.WithString(
+			"compareIndexName",
+			mcp.Description("A second index to run the suite against and diff rankings with (e.g. a staging copy)"),
+		),
+		mcp.WithBoolean(
+			"failuresOnly",
+			mcp.Description("Only include failed cases and regressions in the report"),
+		),
+	)
+
+	mcps.AddTool(runSuiteTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		path, _ := req.Params.Arguments["path"].(string)
+		suiteStr, _ := req.Params.Arguments["suite"].(string)
+
+		var s Suite
+		var err error
+		switch {
+		case path != "":
+			s, err = LoadSuite(path)
+		case suiteStr != "":
+			s, err = ParseSuite([]byte(suiteStr), !strings.HasPrefix(strings.TrimSpace(suiteStr), "{"))
+		default:
+			return mcp.NewToolResultError("either path or suite is required"), nil
+		}
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		if indexName, ok := req.Params.Arguments["indexName"].(string); ok && indexName != "" {
+			s.IndexName = indexName
+		}
+		if s.IndexName == "" {
+			s.IndexName = index.GetName()
+		}
+		if compare, ok := req.Params.Arguments["compareIndexName"].(string); ok && compare != "" {
+			s.CompareIndexName = compare
+		}
+
+		report := Run(client, s)
+		if failuresOnly, _ := req.Params.Arguments["failuresOnly"].(bool); failuresOnly {
+			report.Cases = Failures(report.Cases)
+		}
+
+		return mcputil.JSONToolResult(fmt.Sprintf("relevance suite (%d passed, %d failed)", report.Passed, report.Failed), report)
+	})
+}
+
+// Failures returns the cases that failed or regressed on the compare index.
+func Failures(cases []CaseResult) []CaseResult {
+	var failed []CaseResult
+	for _, c := range cases {
+		if !c.Passed || (c.Compare != nil && !c.Compare.Passed) {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,142 @@
+package relevance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Assertion types supported by a relevance test case.
+const (
+	AssertPosition = "position"
+	AssertPresent  = "present"
+	AssertAbsent   = "absent"
+	AssertNbHits   = "nbHits"
+	AssertTop      = "top"
+)
+
+// Suite is a list of golden queries run against an index.
+type Suite struct {
+	// IndexName is the index the cases run against, unless a case overrides it.
+	IndexName string `json:"indexName,omitempty"`
+	// CompareIndexName is an optional second index (e.g. a staging copy) the
+	// cases are also run against, to catch regressions between the two.
+	CompareIndexName string `json:"compareIndexName,omitempty"`
+	// Params are search parameters applied to every case.
+	Params map[string]any `json:"params,omitempty"`
+	Cases  []Case         `json:"cases"`
+}
+
+// Case is a single query and the assertions made on its results.
+type Case struct {
+	Name       string         `json:"name,omitempty"`
+	Query      string         `json:"query"`
+	IndexName  string         `json:"indexName,omitempty"`
+	Params     map[string]any `json:"params,omitempty"`
+	Assertions []Assertion    `json:"assertions"`
+}
+
+// Assertion is an expectation on the results of a case.
+//
+//   - position: ObjectID is at exactly Position (1-based).
+//   - present: ObjectID is in the first Within hits (all returned hits when unset).
+//   - absent: ObjectID is not in the first Within hits (all returned hits when unset).
+//   - nbHits: the total number of hits is between Min and Max (both optional).
+//   - top: the first hits are exactly ObjectIDs, in order.
+type Assertion struct {
+	Type      string   `json:"type"`
+	ObjectID  string   `json:"objectID,omitempty"`
+	ObjectIDs []string `json:"objectIDs,omitempty"`
+	Position  int      `json:"position,omitempty"`
+	Within    int      `json:"within,omitempty"`
+	Min       *int     `json:"min,omitempty"`
+	Max       *int     `json:"max,omitempty"`
+}
+
+// LoadSuite reads a suite from a YAML or JSON file.
+func LoadSuite(path string) (Suite, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Suite{}, fmt.Errorf("could not read suite: %w", err)
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	return ParseSuite(b, ext == ".yaml" || ext == ".yml")
+}
+
+// ParseSuite parses a suite from YAML or JSON and validates it.
+func ParseSuite(b []byte, isYAML bool) (Suite, error) {
+	// YAML is decoded generically and re-encoded as JSON so that a single
+	// set of struct tags describes the format.
+	if isYAML {
+		var v any
+		if err := yaml.Unmarshal(b, &v); err != nil {
+			return Suite{}, fmt.Errorf("invalid YAML: %w", err)
+		}
+		var err error
+		if b, err = json.Marshal(v); err != nil {
+			return Suite{}, fmt.Errorf("could not convert YAML: %w", err)
+		}
+	}
+
+	var s Suite
+	if err := json.Unmarshal(b, &s); err != nil {
+		return Suite{}, fmt.Errorf("invalid suite: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return Suite{}, err
+	}
+	return s, nil
+}
+
+// Validate checks that every case and assertion is well-formed.
+func (s Suite) Validate() error {
+	if len(s.Cases) == 0 {
+		return fmt.Errorf("suite has no cases")
+	}
+	for i, c := range s.Cases {
+		if len(c.Assertions) == 0 {
+			return fmt.Errorf("case %d (%s) has no assertions", i, c.label())
+		}
+		for j, a := range c.Assertions {
+			if err := a.validate(); err != nil {
+				return fmt.Errorf("case %d (%s), assertion %d: %w", i, c.label(), j, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (a Assertion) validate() error {
+	switch a.Type {
+	case AssertPosition:
+		if a.ObjectID == "" || a.Position < 1 {
+			return fmt.Errorf("position assertions need an objectID and a position >= 1")
+		}
+	case AssertPresent, AssertAbsent:
+		if a.ObjectID == "" {
+			return fmt.Errorf("%s assertions need an objectID", a.Type)
+		}
+	case AssertNbHits:
+		if a.Min == nil && a.Max == nil {
+			return fmt.Errorf("nbHits assertions need a min or a max")
+		}
+	case AssertTop:
+		if len(a.ObjectIDs) == 0 {
+			return fmt.Errorf("top assertions need objectIDs")
+		}
+	default:
+		return fmt.Errorf("unknown assertion type %q", a.Type)
+	}
+	return nil
+}
+
+func (c Case) label() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return fmt.Sprintf("%q", c.Query)
+}
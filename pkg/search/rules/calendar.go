@@ -0,0 +1,332 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+)
+
+// defaultCalendarDays is the length of the calendar window when no end is given.
+const defaultCalendarDays = 30
+
+// RuleRef identifies a rule of an index.
+type RuleRef struct {
+	IndexName   string `json:"indexName"`
+	ObjectID    string `json:"objectID"`
+	Description string `json:"description,omitempty"`
+}
+
+// CalendarEvent is a rule activating or expiring.
+type CalendarEvent struct {
+	RuleRef
+	Time  time.Time `json:"time"`
+	Event string    `json:"event"`
+}
+
+// RuleConflict is a pair of rules active at the same time for the same
+// condition, whose promotions contradict each other.
+type RuleConflict struct {
+	IndexName string    `json:"indexName"`
+	Pattern   string    `json:"pattern"`
+	Anchoring string    `json:"anchoring"`
+	Context   string    `json:"context,omitempty"`
+	Rules     []string  `json:"rules"`
+	From      time.Time `json:"from"`
+	Until     time.Time `json:"until"`
+	Reasons   []string  `json:"reasons"`
+}
+
+// ExpiredRule is a rule whose validity windows are all in the past.
+type ExpiredRule struct {
+	RuleRef
+	ExpiredAt time.Time `json:"expiredAt"`
+}
+
+// RulesCalendar is what activates and expires on a set of indices in a window.
+type RulesCalendar struct {
+	From          time.Time       `json:"from"`
+	Until         time.Time       `json:"until"`
+	Indices       []string        `json:"indices"`
+	Rules         int             `json:"rules"`
+	Disabled      int             `json:"disabled"`
+	ActiveAtStart []RuleRef       `json:"activeAtStart"`
+	Events        []CalendarEvent `json:"events"`
+	Conflicts     []RuleConflict  `json:"conflicts"`
+	Expired       []ExpiredRule   `json:"expired"`
+}
+
+func RegisterRulesCalendar(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+	rulesCalendarTool := mcp.NewTool(
+		"rules_calendar",
+		mcp.WithDescription("List the rules of one or more indices as a timeline of what activates and expires in a time window. Flags rules with the same condition that are active at the same time and promote conflicting objectIDs, and rules that have already expired and can be deleted"),
+		mcp.WithString(
+			"indexNames",
+			mcp.Description("Comma-separated list of indices (defaults to the configured index)"),
+		),
+		mcp.WithString(
+			"from",
+			mcp.Description("Start of the window, as an RFC 3339 date (default: now)"),
+		),
+		mcp.WithString(
+			"until",
+			mcp.Description(fmt.Sprintf("End of the window, as an RFC 3339 date (default: %d days after from)", defaultCalendarDays)),
+		),
+	)
+
+	mcps.AddTool(rulesCalendarTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		now := time.Now().UTC()
+		from := now
+		if s, ok := req.Params.Arguments["from"].(string); ok && s != "" {
+			t, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid from: %v", err)), nil
+			}
+			from = t
+		}
+		until := from.AddDate(0, 0, defaultCalendarDays)
+		if s, ok := req.Params.Arguments["until"].(string); ok && s != "" {
+			t, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid until: %v", err)), nil
+			}
+			until = t
+		}
+		if !until.After(from) {
+			return mcp.NewToolResultError("until must be after from"), nil
+		}
+
+		var names []string
+		s, _ := req.Params.Arguments["indexNames"].(string)
+		for _, name := range mcputil.SplitList(s) {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			if index == nil {
+				return mcp.NewToolResultError("indexNames is required when no index is configured"), nil
+			}
+			names = []string{index.GetName()}
+		}
+
+		cal := RulesCalendar{
+			From:          from,
+			Until:         until,
+			Indices:       names,
+			ActiveAtStart: []RuleRef{},
+			Events:        []CalendarEvent{},
+			Conflicts:     []RuleConflict{},
+			Expired:       []ExpiredRule{},
+		}
+		for _, name := range names {
+			rules, err := searchutil.GetAllRules(client.InitIndex(name))
+			if err != nil {
+				return nil, fmt.Errorf("could not search rules of %s: %w", name, err)
+			}
+			cal.add(name, rules, now)
+		}
+		sort.SliceStable(cal.Events, func(i, j int) bool { return cal.Events[i].Time.Before(cal.Events[j].Time) })
+
+		return mcputil.JSONToolResult("rules calendar", cal)
+	})
+}
+
+// window is a time range in which a rule is active. Rules without validity
+// are active from the zero time to maxTime.
+type window struct {
+	from, until time.Time
+}
+
+var maxTime = time.Unix(1<<62, 0)
+
+func ruleWindows(r search.Rule) []window {
+	if len(r.Validity) == 0 {
+		return []window{{until: maxTime}}
+	}
+	windows := make([]window, len(r.Validity))
+	for i, v := range r.Validity {
+		windows[i] = window{from: v.From, until: v.Until}
+	}
+	return windows
+}
+
+func overlap(a, b window) (window, bool) {
+	w := window{from: a.from, until: a.until}
+	if b.from.After(w.from) {
+		w.from = b.from
+	}
+	if b.until.Before(w.until) {
+		w.until = b.until
+	}
+	return w, w.until.After(w.from)
+}
+
+// ruleConditions returns the conditions of a rule, including the deprecated
+// single condition.
+func ruleConditions(r search.Rule) []search.RuleCondition {
+	if len(r.Conditions) > 0 {
+		return r.Conditions
+	}
+	if r.Condition != (search.RuleCondition{}) {
+		return []search.RuleCondition{r.Condition}
+	}
+	return nil
+}
+
+// add adds the rules of an index, keyed by objectID, to the calendar.
+func (cal *RulesCalendar) add(indexName string, rules map[string]search.Rule, now time.Time) {
+	span := window{from: cal.From, until: cal.Until}
+	type active struct {
+		rule    search.Rule
+		windows []window
+	}
+	byCondition := map[search.RuleCondition][]active{}
+	var conditions []search.RuleCondition
+
+	ids := make([]string, 0, len(rules))
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		r := rules[id]
+		cal.Rules++
+		ref := RuleRef{IndexName: indexName, ObjectID: r.ObjectID, Description: r.Description}
+		windows := ruleWindows(r)
+
+		if len(r.Validity) > 0 {
+			var last time.Time
+			for _, w := range windows {
+				if w.until.After(last) {
+					last = w.until
+				}
+			}
+			if last.Before(now) {
+				cal.Expired = append(cal.Expired, ExpiredRule{RuleRef: ref, ExpiredAt: last})
+			}
+		}
+		if !r.Enabled.Get() {
+			cal.Disabled++
+			continue
+		}
+
+		var inSpan []window
+		for _, w := range windows {
+			if !w.from.After(cal.From) && w.until.After(cal.From) {
+				cal.ActiveAtStart = append(cal.ActiveAtStart, ref)
+			}
+			if w.from.After(cal.From) && w.from.Before(cal.Until) {
+				cal.Events = append(cal.Events, CalendarEvent{RuleRef: ref, Time: w.from, Event: "activates"})
+			}
+			if w.until.After(cal.From) && w.until.Before(cal.Until) {
+				cal.Events = append(cal.Events, CalendarEvent{RuleRef: ref, Time: w.until, Event: "expires"})
+			}
+			if o, ok := overlap(w, span); ok {
+				inSpan = append(inSpan, o)
+			}
+		}
+		if len(inSpan) == 0 || (len(r.Consequence.Promote) == 0 && len(r.Consequence.Hide) == 0) {
+			continue
+		}
+		for _, c := range ruleConditions(r) {
+			if c.Pattern == "" && c.Anchoring == "" {
+				continue
+			}
+			key := search.RuleCondition{Pattern: strings.ToLower(c.Pattern), Anchoring: c.Anchoring, Context: c.Context}
+			if _, ok := byCondition[key]; !ok {
+				conditions = append(conditions, key)
+			}
+			byCondition[key] = append(byCondition[key], active{rule: r, windows: inSpan})
+		}
+	}
+
+	for _, c := range conditions {
+		group := byCondition[c]
+		for i := range group {
+			for j := i + 1; j < len(group); j++ {
+				a, b := group[i], group[j]
+				if a.rule.ObjectID == b.rule.ObjectID {
+					continue
+				}
+				reasons := promotionConflicts(a.rule.Consequence, b.rule.Consequence)
+				if len(reasons) == 0 {
+					continue
+				}
+				for _, wa := range a.windows {
+					for _, wb := range b.windows {
+						o, ok := overlap(wa, wb)
+						if !ok {
+							continue
+						}
+						cal.Conflicts = append(cal.Conflicts, RuleConflict{
+							IndexName: indexName,
+							Pattern:   c.Pattern,
+							Anchoring: string(c.Anchoring),
+							Context:   c.Context,
+							Rules:     []string{a.rule.ObjectID, b.rule.ObjectID},
+							From:      o.from,
+							Until:     o.until,
+							Reasons:   reasons,
+						})
+					}
+				}
+			}
+		}
+	}
+}
+
+// promotionConflicts describes how the promotions of two rules contradict
+// each other: different objects pinned at the same position, the same object
+// pinned at different positions, or an object promoted by one and hidden by
+// the other.
+func promotionConflicts(a, b search.RuleConsequence) []string {
+	positions := func(c search.RuleConsequence) map[string]int {
+		m := map[string]int{}
+		for _, p := range c.Promote {
+			if p.ObjectID != "" {
+				m[p.ObjectID] = p.Position
+			}
+			for i, id := range p.ObjectIDs {
+				m[id] = p.Position + i
+			}
+		}
+		return m
+	}
+	pa, pb := positions(a), positions(b)
+
+	var reasons []string
+	atA := map[int]string{}
+	for id, pos := range pa {
+		atA[pos] = id
+	}
+	for id, pos := range pb {
+		if posA, ok := pa[id]; ok && posA != pos {
+			reasons = append(reasons, fmt.Sprintf("%s is promoted at positions %d and %d", id, posA, pos))
+		}
+		if other, ok := atA[pos]; ok && other != id {
+			reasons = append(reasons, fmt.Sprintf("%s and %s are both promoted at position %d", other, id, pos))
+		}
+	}
+	for _, h := range b.Hide {
+		if _, ok := pa[h.ObjectID]; ok {
+			reasons = append(reasons, fmt.Sprintf("%s is promoted by one rule and hidden by the other", h.ObjectID))
+		}
+	}
+	for _, h := range a.Hide {
+		if _, ok := pb[h.ObjectID]; ok {
+			reasons = append(reasons, fmt.Sprintf("%s is promoted by one rule and hidden by the other", h.ObjectID))
+		}
+	}
+	sort.Strings(reasons)
+	return reasons
+}
@@ -0,0 +1,338 @@
+package rules
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSV layout of rules. The first row is a header naming the columns, in any
+// order. Each row is a rule with at most one condition and one validity
+// window in the flat columns:
+//
+//	objectID, description, enabled    the rule
+//	pattern, anchoring, alternatives,  its condition
+//	context, filters
+//	promote                            "id:position" entries separated by ';',
+//	                                   grouped records as "id1,id2:position"
+//	hide                               objectIDs separated by ';'
+//	filterPromotes                     true or false
+//	params, userData                   JSON
+//	validFrom, validUntil              RFC 3339 dates
+//	conditions, validity               JSON arrays, for rules with several
+//	                                   conditions or validity windows
+var ruleCSVColumns = []string{
+	"objectID", "description", "enabled",
+	"pattern", "anchoring", "alternatives", "context", "filters",
+	"promote", "hide", "filterPromotes", "params", "userData",
+	"validFrom", "validUntil", "conditions", "validity",
+}
+
+const ruleListSeparator = ";"
+
+// readRulesCSV reads rules from a CSV file.
+func readRulesCSV(path string) ([]map[string]any, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("could not read the CSV header: %w", err)
+	}
+	for i, h := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+		if !slices.Contains(ruleCSVColumns, header[i]) {
+			return nil, fmt.Errorf("unknown column %q, expected %s", header[i], strings.Join(ruleCSVColumns, ", "))
+		}
+	}
+
+	var rules []map[string]any
+	for line := 2; ; line++ {
+		row, err := r.Read()
+		if err == io.EOF {
+			return rules, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		cells := map[string]string{}
+		empty := true
+		for i, v := range row {
+			if i < len(header) {
+				cells[header[i]] = strings.TrimSpace(v)
+				empty = empty && cells[header[i]] == ""
+			}
+		}
+		if empty {
+			continue
+		}
+		rule, err := rowToRule(cells)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		rules = append(rules, rule)
+	}
+}
+
+func rowToRule(cells map[string]string) (map[string]any, error) {
+	rule := map[string]any{"objectID": cells["objectID"]}
+	if v := cells["description"]; v != "" {
+		rule["description"] = v
+	}
+	if err := setBool(rule, "enabled", cells["enabled"]); err != nil {
+		return nil, err
+	}
+
+	if v := cells["conditions"]; v != "" {
+		var conditions []any
+		if err := json.Unmarshal([]byte(v), &conditions); err != nil {
+			return nil, fmt.Errorf("invalid conditions JSON: %w", err)
+		}
+		rule["conditions"] = conditions
+	} else {
+		cond := map[string]any{}
+		for _, col := range []string{"pattern", "anchoring", "context", "filters"} {
+			if v := cells[col]; v != "" {
+				cond[col] = v
+			}
+		}
+		if err := setBool(cond, "alternatives", cells["alternatives"]); err != nil {
+			return nil, err
+		}
+		if len(cond) > 0 {
+			rule["conditions"] = []any{cond}
+		}
+	}
+
+	consequence := map[string]any{}
+	if v := cells["promote"]; v != "" {
+		promote, err := parsePromote(v)
+		if err != nil {
+			return nil, err
+		}
+		consequence["promote"] = promote
+	}
+	if v := cells["hide"]; v != "" {
+		var hide []any
+		for _, id := range strings.Split(v, ruleListSeparator) {
+			if id = strings.TrimSpace(id); id != "" {
+				hide = append(hide, map[string]any{"objectID": id})
+			}
+		}
+		consequence["hide"] = hide
+	}
+	if err := setBool(consequence, "filterPromotes", cells["filterPromotes"]); err != nil {
+		return nil, err
+	}
+	for _, col := range []string{"params", "userData"} {
+		if v := cells[col]; v != "" {
+			var x any
+			if err := json.Unmarshal([]byte(v), &x); err != nil {
+				return nil, fmt.Errorf("invalid %s JSON: %w", col, err)
+			}
+			consequence[col] = x
+		}
+	}
+	rule["consequence"] = consequence
+
+	switch {
+	case cells["validity"] != "":
+		var validity []any
+		if err := json.Unmarshal([]byte(cells["validity"]), &validity); err != nil {
+			return nil, fmt.Errorf("invalid validity JSON: %w", err)
+		}
+		rule["validity"] = validity
+	case cells["validFrom"] != "" || cells["validUntil"] != "":
+		from, err := time.Parse(time.RFC3339, cells["validFrom"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid validFrom: %w", err)
+		}
+		until, err := time.Parse(time.RFC3339, cells["validUntil"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid validUntil: %w", err)
+		}
+		rule["validity"] = []any{map[string]any{"from": float64(from.Unix()), "until": float64(until.Unix())}}
+	}
+
+	return rule, nil
+}
+
+// parsePromote parses "id:position" entries separated by ';', where grouped
+// records are written "id1,id2:position".
+func parsePromote(s string) ([]any, error) {
+	var promote []any
+	for _, entry := range strings.Split(s, ruleListSeparator) {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		i := strings.LastIndex(entry, ":")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid promote entry %q, expected id:position", entry)
+		}
+		pos, err := strconv.Atoi(strings.TrimSpace(entry[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid position in promote entry %q", entry)
+		}
+		var ids []any
+		for _, id := range strings.Split(entry[:i], ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+		switch len(ids) {
+		case 0:
+			return nil, fmt.Errorf("invalid promote entry %q, expected id:position", entry)
+		case 1:
+			promote = append(promote, map[string]any{"objectID": ids[0], "position": float64(pos)})
+		default:
+			promote = append(promote, map[string]any{"objectIDs": ids, "position": float64(pos)})
+		}
+	}
+	return promote, nil
+}
+
+func setBool(m map[string]any, key, cell string) error {
+	if cell == "" {
+		return nil
+	}
+	b, err := strconv.ParseBool(cell)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q, expected true or false", key, cell)
+	}
+	m[key] = b
+	return nil
+}
+
+// writeRulesCSV writes rules to a CSV file.
+func writeRulesCSV(path string, rules []map[string]any) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write(ruleCSVColumns); err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		cells, err := ruleToRow(rule)
+		if err != nil {
+			return fmt.Errorf("rule %v: %w", rule["objectID"], err)
+		}
+		row := make([]string, len(ruleCSVColumns))
+		for i, col := range ruleCSVColumns {
+			row[i] = cells[col]
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func ruleToRow(rule map[string]any) (map[string]string, error) {
+	cells := map[string]string{}
+	cells["objectID"], _ = rule["objectID"].(string)
+	cells["description"], _ = rule["description"].(string)
+	if v, ok := rule["enabled"].(bool); ok {
+		cells["enabled"] = strconv.FormatBool(v)
+	}
+
+	conditions, _ := rule["conditions"].([]any)
+	if len(conditions) == 1 {
+		cond, _ := conditions[0].(map[string]any)
+		for _, key := range []string{"pattern", "anchoring", "context", "filters"} {
+			cells[key], _ = cond[key].(string)
+		}
+		if v, ok := cond["alternatives"].(bool); ok {
+			cells["alternatives"] = strconv.FormatBool(v)
+		}
+	} else if len(conditions) > 1 {
+		b, err := json.Marshal(conditions)
+		if err != nil {
+			return nil, err
+		}
+		cells["conditions"] = string(b)
+	}
+
+	consequence, _ := rule["consequence"].(map[string]any)
+	if promote, ok := consequence["promote"].([]any); ok {
+		entries := make([]string, 0, len(promote))
+		for _, p := range promote {
+			pm, _ := p.(map[string]any)
+			pos, _ := pm["position"].(float64)
+			var ids []string
+			if id, ok := pm["objectID"].(string); ok {
+				ids = append(ids, id)
+			}
+			list, _ := pm["objectIDs"].([]any)
+			for _, id := range list {
+				ids = append(ids, fmt.Sprint(id))
+			}
+			for _, id := range ids {
+				if strings.ContainsAny(id, ",:;") {
+					return nil, fmt.Errorf("promoted objectID %q contains a separator, export the rules as JSON instead", id)
+				}
+			}
+			entries = append(entries, fmt.Sprintf("%s:%d", strings.Join(ids, ","), int(pos)))
+		}
+		cells["promote"] = strings.Join(entries, ruleListSeparator)
+	}
+	if hide, ok := consequence["hide"].([]any); ok {
+		ids := make([]string, 0, len(hide))
+		for _, h := range hide {
+			hm, _ := h.(map[string]any)
+			id := fmt.Sprint(hm["objectID"])
+			if strings.Contains(id, ruleListSeparator) {
+				return nil, fmt.Errorf("hidden objectID %q contains %q, export the rules as JSON instead", id, ruleListSeparator)
+			}
+			ids = append(ids, id)
+		}
+		cells["hide"] = strings.Join(ids, ruleListSeparator)
+	}
+	if v, ok := consequence["filterPromotes"].(bool); ok {
+		cells["filterPromotes"] = strconv.FormatBool(v)
+	}
+	for _, key := range []string{"params", "userData"} {
+		if v, ok := consequence[key]; ok {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			cells[key] = string(b)
+		}
+	}
+
+	validity, _ := rule["validity"].([]any)
+	if len(validity) == 1 {
+		r, _ := validity[0].(map[string]any)
+		from, _ := r["from"].(float64)
+		until, _ := r["until"].(float64)
+		cells["validFrom"] = time.Unix(int64(from), 0).UTC().Format(time.RFC3339)
+		cells["validUntil"] = time.Unix(int64(until), 0).UTC().Format(time.RFC3339)
+	} else if len(validity) > 1 {
+		b, err := json.Marshal(validity)
+		if err != nil {
+			return nil, err
+		}
+		cells["validity"] = string(b)
+	}
+
+	return cells, nil
+}
@@ -6,11 +6,12 @@ import (
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
-func RegisterDeleteRule(mcps *server.MCPServer, index *search.Index) {
+func RegisterDeleteRule(mcps *server.MCPServer, client *search.Client, index *search.Index) {
 	deleteRuleTool := mcp.NewTool(
 		"delete_rule",
 		mcp.WithDescription("Delete a rule by its object ID"),
@@ -19,6 +20,7 @@ func RegisterDeleteRule(mcps *server.MCPServer, index *search.Index) {
 			mcp.Description("The object ID to delete"),
 			mcp.Required(),
 		),
+		searchutil.WithIndexName(),
 	)
 
 	mcps.AddTool(deleteRuleTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -27,7 +29,7 @@ func RegisterDeleteRule(mcps *server.MCPServer, index *search.Index) {
 			return mcp.NewToolResultError("invalid object format, expected JSON string"), nil
 		}
 
-		resp, err := index.DeleteRule(objectID)
+		resp, err := searchutil.Index(client, index, req).DeleteRule(objectID)
 		if err != nil {
 			return nil, fmt.Errorf("could not delete rule: %w", err)
 		}
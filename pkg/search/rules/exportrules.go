@@ -0,0 +1,62 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+)
+
+func RegisterExportRules(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+	exportRulesTool := mcp.NewTool(
+		"export_rules",
+		mcp.WithDescription("Export every rule of an index to a local CSV file, with one row per rule (see the README for the columns), or to a JSON file in the dashboard export format. Rules with several conditions or validity windows keep them as JSON in the conditions and validity columns"),
+		mcp.WithString(
+			"path",
+			mcp.Description("Path of the file to write"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"format",
+			mcp.Description("File format (defaults to the file extension)"),
+			mcp.Enum("csv", "json"),
+		),
+		searchutil.WithIndexName(),
+	)
+
+	mcps.AddTool(exportRulesTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		path, _ := req.Params.Arguments["path"].(string)
+		formatArg, _ := req.Params.Arguments["format"].(string)
+		format, err := searchutil.FileFormat(path, formatArg)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		indexName := searchutil.Index(client, index, req).GetName()
+
+		rules, err := searchutil.GetAllObjects(client.InitIndex(indexName), "rules")
+		if err != nil {
+			return nil, fmt.Errorf("could not get rules: %w", err)
+		}
+
+		if format == "csv" {
+			err = writeRulesCSV(path, rules)
+		} else {
+			err = searchutil.WriteJSONObjects(path, rules)
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not write %s: %v", path, err)), nil
+		}
+
+		return mcputil.JSONToolResult("export result", map[string]any{
+			"indexName": indexName,
+			"path":      path,
+			"format":    format,
+			"count":     len(rules),
+		})
+	})
+}
@@ -0,0 +1,95 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+)
+
+func RegisterImportRules(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+	importRulesTool := mcp.NewTool(
+		"import_rules",
+		mcp.WithDescription("Import rules from a local CSV file (same layout as export_rules) or JSON file (dashboard export format). Every rule is validated against the Search API schema, then the added, changed and removed rules are returned. The rules are only saved when apply is true"),
+		mcp.WithString(
+			"path",
+			mcp.Description("Path of the file to read"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"format",
+			mcp.Description("File format (defaults to the file extension)"),
+			mcp.Enum("csv", "json"),
+		),
+		mcp.WithString(
+			"mode",
+			mcp.Description("merge adds and updates the imported rules and keeps the others, replace also deletes the rules missing from the file (default: merge)"),
+			mcp.Enum(searchutil.ImportMerge, searchutil.ImportReplace),
+		),
+		mcp.WithBoolean(
+			"apply",
+			mcp.Description("Save the rules. When false or omitted, only the diff is returned"),
+		),
+		mcp.WithBoolean(
+			"forwardToReplicas",
+			mcp.Description("Whether to also import the rules to the replicas of the index"),
+		),
+		searchutil.WithIndexName(),
+	)
+
+	mcps.AddTool(importRulesTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if index == nil {
+			return mcp.NewToolResultError("write API key not set, cannot import rules"), nil
+		}
+		path, _ := req.Params.Arguments["path"].(string)
+		formatArg, _ := req.Params.Arguments["format"].(string)
+		mode, _ := req.Params.Arguments["mode"].(string)
+		if mode == "" {
+			mode = searchutil.ImportMerge
+		}
+		apply, _ := req.Params.Arguments["apply"].(bool)
+		forwardToReplicas, _ := req.Params.Arguments["forwardToReplicas"].(bool)
+
+		format, err := searchutil.FileFormat(path, formatArg)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		var rules []map[string]any
+		if format == "csv" {
+			rules, err = readRulesCSV(path)
+		} else {
+			rules, err = searchutil.ReadJSONObjects(path)
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not read %s: %v", path, err)), nil
+		}
+		for i, rule := range rules {
+			errs, err := validateRule(rule)
+			if err != nil {
+				return nil, err
+			}
+			if len(errs) > 0 {
+				b, _ := json.MarshalIndent(errs, "", "  ")
+				return mcp.NewToolResultError(fmt.Sprintf("invalid rule %v at index %d, no rule was saved:\n%s", rule["objectID"], i, b)), nil
+			}
+		}
+
+		res := searchutil.ImportResult{
+			IndexName: searchutil.Index(client, index, req).GetName(),
+			Path:      path,
+			Format:    format,
+			Mode:      mode,
+		}
+		if err := searchutil.ImportObjects(client, &res, "rules", rules, apply, forwardToReplicas); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		return mcputil.JSONToolResult("import result", res)
+	})
+}
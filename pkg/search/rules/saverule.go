@@ -0,0 +1,379 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/relevance"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+)
+
+// defaultTestHitsPerPage is the number of hits compared by testQuery.
+const defaultTestHitsPerPage = 20
+
+// RuleTest compares the results of a query without and with a rule.
+type RuleTest struct {
+	Query     string                `json:"query"`
+	TmpIndex  string                `json:"tmpIndexName"`
+	Without   RuleTestResult        `json:"without"`
+	With      RuleTestResult        `json:"with"`
+	Diff      relevance.RankingDiff `json:"diff"`
+	Triggered bool                  `json:"triggered"`
+}
+
+// RuleTestResult is the outcome of a test query.
+type RuleTestResult struct {
+	NbHits   int      `json:"nbHits"`
+	Top      []string `json:"top"`
+	Query    string   `json:"parsedQuery,omitempty"`
+	UserData any      `json:"userData,omitempty"`
+}
+
+// SaveRuleResult is the outcome of save_rule.
+type SaveRuleResult struct {
+	Rule  map[string]any `json:"rule"`
+	Saved bool           `json:"saved"`
+	Task  any            `json:"task,omitempty"`
+	Test  *RuleTest      `json:"test,omitempty"`
+}
+
+func RegisterSaveRule(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+	saveRuleTool := mcp.NewTool(
+		"save_rule",
+		mcp.WithDescription("Create or replace a rule from structured arguments. The rule is validated locally against the Search API schema before being saved, and can be tested against a query on a temporary copy of the index first"),
+		mcp.WithString(
+			"objectID",
+			mcp.Description("Unique identifier of the rule"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"description",
+			mcp.Description("Description of the rule's purpose"),
+		),
+		mcp.WithBoolean(
+			"enabled",
+			mcp.Description("Whether the rule is active (default: true)"),
+		),
+		mcp.WithString(
+			"pattern",
+			mcp.Description("Query pattern that triggers the rule, a literal string or {facet:ATTRIBUTE}. Requires anchoring"),
+		),
+		mcp.WithString(
+			"anchoring",
+			mcp.Description("Which part of the query the pattern must match"),
+			mcp.Enum("is", "startsWith", "endsWith", "contains"),
+		),
+		mcp.WithBoolean(
+			"alternatives",
+			mcp.Description("Whether the pattern also matches plurals, synonyms and typos"),
+		),
+		mcp.WithString(
+			"context",
+			mcp.Description("Rule context that triggers the rule, matched against the ruleContexts search parameter"),
+		),
+		mcp.WithString(
+			"conditionFilters",
+			mcp.Description("Filters that trigger the rule, e.g. 'genre:comedy'"),
+		),
+		mcp.WithString(
+			"conditions",
+			mcp.Description("Conditions as a JSON array, to declare several conditions instead of pattern, anchoring, alternatives, context and conditionFilters. Example: [{\"pattern\":\"shoes\",\"anchoring\":\"contains\"},{\"context\":\"summer\"}]"),
+		),
+		mcp.WithString(
+			"promote",
+			mcp.Description("Records to pin, as a JSON array of {\"objectID\",\"position\"} or {\"objectIDs\",\"position\"} objects. Positions are 0-based. Example: [{\"objectID\":\"123\",\"position\":0}]"),
+		),
+		mcp.WithBoolean(
+			"filterPromotes",
+			mcp.Description("Whether promoted records must match the active filters to be promoted"),
+		),
+		mcp.WithString(
+			"hide",
+			mcp.Description("Comma-separated list of objectIDs to hide from the results"),
+		),
+		mcp.WithString(
+			"params",
+			mcp.Description("Search parameters applied by the rule, as a JSON object. Example: {\"filters\":\"brand:nike\",\"query\":{\"remove\":[\"cheap\"]}}"),
+		),
+		mcp.WithString(
+			"userData",
+			mcp.Description("Custom JSON data returned with the results when the rule is triggered, e.g. a banner"),
+		),
+		mcp.WithString(
+			"validFrom",
+			mcp.Description("Start of the validity window, in RFC 3339 format (e.g. 2025-11-28T00:00:00Z). Requires validUntil"),
+		),
+		mcp.WithString(
+			"validUntil",
+			mcp.Description("End of the validity window, in RFC 3339 format. Requires validFrom"),
+		),
+		mcp.WithString(
+			"validity",
+			mcp.Description("Validity windows as a JSON array of {\"from\",\"until\"} Unix timestamps, to declare several windows instead of validFrom and validUntil"),
+		),
+		mcp.WithBoolean(
+			"forwardToReplicas",
+			mcp.Description("Whether to also save the rule on the replicas of the index"),
+		),
+		mcp.WithString(
+			"testQuery",
+			mcp.Description("A query to run without and with the rule, on a temporary copy of the index, to report the ranking difference before saving"),
+		),
+		mcp.WithString(
+			"testParams",
+			mcp.Description("Search parameters for testQuery, as a JSON object. The context of the rule is added to ruleContexts"),
+		),
+		mcp.WithBoolean(
+			"dryRun",
+			mcp.Description("Only validate the rule, and run testQuery if given, without saving it"),
+		),
+		searchutil.WithIndexName(),
+	)
+
+	mcps.AddTool(saveRuleTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if index == nil {
+			return mcp.NewToolResultError("write API key not set, cannot save rules"), nil
+		}
+		args := req.Params.Arguments
+
+		rule, err := buildRule(args)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		errs, err := validateRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		if len(errs) > 0 {
+			b, _ := json.MarshalIndent(errs, "", "  ")
+			return mcp.NewToolResultError(fmt.Sprintf("invalid rule, it was not saved:\n%s", b)), nil
+		}
+
+		idx := searchutil.Index(client, index, req)
+		res := SaveRuleResult{Rule: rule}
+
+		if q, ok := args["testQuery"].(string); ok {
+			params := map[string]any{}
+			if s, ok := args["testParams"].(string); ok && s != "" {
+				if err := json.Unmarshal([]byte(s), &params); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid testParams JSON: %v", err)), nil
+				}
+			}
+			test, err := testRule(client, idx, rule, q, params)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			res.Test = test
+		}
+
+		if dryRun, _ := args["dryRun"].(bool); dryRun {
+			return mcputil.JSONToolResult("rule preview", res)
+		}
+
+		forwardToReplicas, _ := args["forwardToReplicas"].(bool)
+		task, err := saveRawRule(client, idx.GetName(), rule, forwardToReplicas)
+		if err != nil {
+			return nil, fmt.Errorf("could not save rule: %w", err)
+		}
+		res.Saved = true
+		res.Task = task
+
+		return mcputil.JSONToolResult("rule", res)
+	})
+}
+
+// buildRule assembles a rule object from the tool arguments.
+func buildRule(args map[string]any) (map[string]any, error) {
+	objectID, _ := args["objectID"].(string)
+	rule := map[string]any{"objectID": objectID}
+	if v, ok := args["description"].(string); ok && v != "" {
+		rule["description"] = v
+	}
+	if v, ok := args["enabled"].(bool); ok {
+		rule["enabled"] = v
+	}
+
+	if s, ok := args["conditions"].(string); ok && s != "" {
+		var conditions []any
+		if err := json.Unmarshal([]byte(s), &conditions); err != nil {
+			return nil, fmt.Errorf("invalid conditions JSON: %w", err)
+		}
+		rule["conditions"] = conditions
+	} else {
+		cond := map[string]any{}
+		for _, key := range []string{"pattern", "anchoring", "context"} {
+			if v, ok := args[key].(string); ok {
+				cond[key] = v
+			}
+		}
+		if v, ok := args["conditionFilters"].(string); ok && v != "" {
+			cond["filters"] = v
+		}
+		if v, ok := args["alternatives"].(bool); ok {
+			cond["alternatives"] = v
+		}
+		if len(cond) > 0 {
+			rule["conditions"] = []any{cond}
+		}
+	}
+
+	consequence := map[string]any{}
+	for _, key := range []string{"promote", "params", "userData"} {
+		if s, ok := args[key].(string); ok && s != "" {
+			var v any
+			if err := json.Unmarshal([]byte(s), &v); err != nil {
+				return nil, fmt.Errorf("invalid %s JSON: %w", key, err)
+			}
+			consequence[key] = v
+		}
+	}
+	if v, ok := args["filterPromotes"].(bool); ok {
+		consequence["filterPromotes"] = v
+	}
+	if s, ok := args["hide"].(string); ok && s != "" {
+		var hide []any
+		for _, id := range mcputil.SplitList(s) {
+			hide = append(hide, map[string]any{"objectID": id})
+		}
+		consequence["hide"] = hide
+	}
+	rule["consequence"] = consequence
+
+	if s, ok := args["validity"].(string); ok && s != "" {
+		var validity []any
+		if err := json.Unmarshal([]byte(s), &validity); err != nil {
+			return nil, fmt.Errorf("invalid validity JSON: %w", err)
+		}
+		rule["validity"] = validity
+	} else {
+		fromStr, _ := args["validFrom"].(string)
+		untilStr, _ := args["validUntil"].(string)
+		if fromStr != "" || untilStr != "" {
+			if fromStr == "" || untilStr == "" {
+				return nil, fmt.Errorf("validFrom and validUntil must be set together")
+			}
+			from, err := time.Parse(time.RFC3339, fromStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid validFrom: %w", err)
+			}
+			until, err := time.Parse(time.RFC3339, untilStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid validUntil: %w", err)
+			}
+			rule["validity"] = []any{map[string]any{"from": float64(from.Unix()), "until": float64(until.Unix())}}
+		}
+	}
+
+	return rule, nil
+}
+
+// saveRawRule saves a rule given as a JSON object, so that fields unknown to
+// the client are kept.
+func saveRawRule(client *search.Client, indexName string, rule map[string]any, forwardToReplicas bool) (search.UpdateTaskRes, error) {
+	var res search.UpdateTaskRes
+	objectID, _ := rule["objectID"].(string)
+	path := fmt.Sprintf("/1/indexes/%s/rules/%s", url.PathEscape(indexName), url.PathEscape(objectID))
+	err := client.CustomRequest(&res, http.MethodPut, path, rule, call.Write,
+		opt.ForwardToReplicas(forwardToReplicas),
+	)
+	return res, err
+}
+
+// testRule runs a query on a temporary copy of the index without, then with
+// the rule, and deletes the copy.
+func testRule(client *search.Client, index *search.Index, rule map[string]any, query string, params map[string]any) (test *RuleTest, err error) {
+	tmpName := fmt.Sprintf("%s_tmp_rule_test_%d", index.GetName(), time.Now().Unix())
+	test = &RuleTest{Query: query, TmpIndex: tmpName}
+
+	copyRes, err := client.CopyIndex(index.GetName(), tmpName)
+	if err != nil {
+		return nil, fmt.Errorf("could not copy %s: %w", index.GetName(), err)
+	}
+	tmp := client.InitIndex(tmpName)
+	defer func() {
+		if _, delErr := tmp.Delete(); delErr != nil && err == nil {
+			err = fmt.Errorf("could not delete %s: %w", tmpName, delErr)
+		}
+	}()
+	if err := copyRes.Wait(); err != nil {
+		return nil, fmt.Errorf("could not wait for the copy: %w", err)
+	}
+
+	p := map[string]any{
+		"attributesToRetrieve": []string{"objectID"},
+		"hitsPerPage":          defaultTestHitsPerPage,
+	}
+	for k, v := range params {
+		p[k] = v
+	}
+	if conditions, ok := rule["conditions"].([]any); ok {
+		var contexts []any
+		if existing, ok := p["ruleContexts"].([]any); ok {
+			contexts = existing
+		}
+		for _, c := range conditions {
+			if ctx, ok := c.(map[string]any)["context"].(string); ok && ctx != "" {
+				contexts = append(contexts, ctx)
+			}
+		}
+		if len(contexts) > 0 {
+			p["ruleContexts"] = contexts
+		}
+	}
+
+	if test.Without, err = runTestQuery(tmp, query, p); err != nil {
+		return nil, err
+	}
+
+	// A disabled rule would never be triggered: test it as if it were enabled.
+	enabled := make(map[string]any, len(rule))
+	for k, v := range rule {
+		enabled[k] = v
+	}
+	enabled["enabled"] = true
+	saveRes, err := saveRawRule(client, tmpName, enabled, false)
+	if err != nil {
+		return nil, fmt.Errorf("could not save the rule on %s: %w", tmpName, err)
+	}
+	if err := tmp.WaitTask(saveRes.TaskID); err != nil {
+		return nil, fmt.Errorf("could not wait for the rule: %w", err)
+	}
+
+	if test.With, err = runTestQuery(tmp, query, p); err != nil {
+		return nil, err
+	}
+	test.Diff = relevance.DiffRanking(test.Without.Top, test.With.Top)
+	test.Triggered = len(test.Diff.Added)+len(test.Diff.Removed)+len(test.Diff.Moved) > 0 ||
+		test.With.NbHits != test.Without.NbHits ||
+		test.With.Query != test.Without.Query ||
+		!reflect.DeepEqual(test.With.UserData, test.Without.UserData)
+	return test, nil
+}
+
+func runTestQuery(index *search.Index, query string, params map[string]any) (RuleTestResult, error) {
+	res, err := index.Search(query, opt.ExtraOptions(params))
+	if err != nil {
+		return RuleTestResult{}, fmt.Errorf("could not search: %w", err)
+	}
+	r := RuleTestResult{NbHits: res.NbHits, Query: res.ParsedQuery}
+	if len(res.UserData) > 0 {
+		r.UserData = res.UserData
+	}
+	for _, h := range res.Hits {
+		id, _ := h["objectID"].(string)
+		r.Top = append(r.Top, id)
+	}
+	return r, nil
+}
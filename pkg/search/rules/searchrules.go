@@ -10,9 +10,10 @@ import (
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
 )
 
-func RegisterSearchRules(mcps *server.MCPServer, index *search.Index) {
+func RegisterSearchRules(mcps *server.MCPServer, client *search.Client, index *search.Index) {
 	searchRulesTool := mcp.NewTool(
 		"search_rules",
 		mcp.WithDescription("Search for rules in the Algolia index"),
@@ -34,6 +35,7 @@ func RegisterSearchRules(mcps *server.MCPServer, index *search.Index) {
 			"enabled",
 			mcp.Description("When specified, restricts matches to rules with a specific enabled status. When omitted, all enabled statuses may match."),
 		),
+		searchutil.WithIndexName(),
 	)
 
 	mcps.AddTool(searchRulesTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -50,7 +52,7 @@ func RegisterSearchRules(mcps *server.MCPServer, index *search.Index) {
 			opts = append(opts, opt.EnableRules(enabled))
 		}
 
-		resp, err := index.SearchRules(query, opts...)
+		resp, err := searchutil.Index(client, index, req).SearchRules(query, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("could not search rules: %w", err)
 		}
@@ -0,0 +1,111 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/algolia/mcp/data"
+	"github.com/algolia/mcp/pkg/search/recordschema"
+)
+
+var ruleSchema = sync.OnceValues(func() (*recordschema.Schema, error) {
+	b, err := ruleSchemaFromSpec(data.SearchSpec)
+	if err != nil {
+		return nil, err
+	}
+	return recordschema.Parse(b)
+})
+
+// ruleSchemaFromSpec returns the rule schema of the Search API specification,
+// with the schemas it references. The allOf of consequenceParams is flattened
+// into a single object, as the API merges its parts rather than validating
+// them one by one: each part forbids the properties of the others.
+func ruleSchemaFromSpec(spec []byte) ([]byte, error) {
+	var doc struct {
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("invalid Search API specification: %w", err)
+	}
+	schemas := doc.Components.Schemas
+	if _, ok := schemas["rule"]; !ok {
+		return nil, fmt.Errorf("no rule schema in the Search API specification")
+	}
+
+	params, _ := schemas["consequenceParams"].(map[string]any)
+	if parts, ok := params["allOf"].([]any); ok {
+		properties := map[string]any{}
+		for _, p := range parts {
+			ref, _ := p.(map[string]any)["$ref"].(string)
+			name := strings.TrimPrefix(ref, "#/components/schemas/")
+			part, _ := schemas[name].(map[string]any)
+			props, ok := part["properties"].(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("unexpected consequenceParams part %q in the Search API specification", ref)
+			}
+			maps.Copy(properties, props)
+		}
+		schemas["consequenceParams"] = map[string]any{
+			"type":                 "object",
+			"additionalProperties": false,
+			"properties":           properties,
+		}
+	}
+
+	return json.Marshal(map[string]any{
+		"$ref":       "#/components/schemas/rule",
+		"components": map[string]any{"schemas": schemas},
+	})
+}
+
+// validateRule checks a rule against the Search API schema, and for the
+// constraints the schema can't express.
+func validateRule(rule map[string]any) ([]recordschema.Error, error) {
+	s, err := ruleSchema()
+	if err != nil {
+		return nil, fmt.Errorf("invalid rule schema: %w", err)
+	}
+	errs := s.Validate(rule)
+
+	conditions, _ := rule["conditions"].([]any)
+	for i, c := range conditions {
+		cond, _ := c.(map[string]any)
+		path := fmt.Sprintf("/conditions/%d", i)
+		pattern, hasPattern := cond["pattern"].(string)
+		anchoring, hasAnchoring := cond["anchoring"].(string)
+		switch {
+		case hasPattern && !hasAnchoring:
+			errs = append(errs, recordschema.Error{Path: path + "/anchoring", Message: "is required with a pattern"})
+		case hasAnchoring && !hasPattern:
+			errs = append(errs, recordschema.Error{Path: path + "/pattern", Message: "is required with an anchoring"})
+		case hasPattern && pattern == "" && anchoring != "is":
+			errs = append(errs, recordschema.Error{Path: path + "/anchoring", Message: "must be \"is\" for an empty pattern"})
+		}
+		if _, ok := cond["filters"]; !ok && !hasPattern && cond["context"] == nil {
+			errs = append(errs, recordschema.Error{Path: path, Message: "must have a pattern, filters or a context"})
+		}
+	}
+
+	if consequence, ok := rule["consequence"].(map[string]any); ok && len(consequence) == 0 {
+		errs = append(errs, recordschema.Error{Path: "/consequence", Message: "must have at least one effect"})
+	}
+
+	validity, _ := rule["validity"].([]any)
+	for i, v := range validity {
+		r, _ := v.(map[string]any)
+		from, _ := r["from"].(float64)
+		until, _ := r["until"].(float64)
+		if until <= from {
+			errs = append(errs, recordschema.Error{Path: fmt.Sprintf("/validity/%d/until", i), Message: "must be after from"})
+		}
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Path < errs[j].Path })
+	return errs, nil
+}
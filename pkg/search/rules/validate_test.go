@@ -0,0 +1,60 @@
+package rules
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestValidateRule(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		paths []string
+	}{
+		{
+			name:  "valid",
+			rule:  `{"objectID": "a", "conditions": [{"pattern": "phone", "anchoring": "contains"}], "consequence": {"params": {"query": "smartphone", "filters": "brand:apple", "hitsPerPage": 5}}}`,
+			paths: nil,
+		},
+		{
+			name:  "unknown parameter",
+			rule:  `{"objectID": "a", "consequence": {"params": {"bogus": 1}}}`,
+			paths: []string{"/consequence/params/bogus"},
+		},
+		{
+			name:  "invalid anchoring and hidden objectID",
+			rule:  `{"objectID": "a", "conditions": [{"pattern": "x", "anchoring": "nope"}], "consequence": {"hide": [{"objectID": 2}]}}`,
+			paths: []string{"/conditions/0/anchoring", "/consequence/hide/0/objectID"},
+		},
+		{
+			name:  "pattern without anchoring",
+			rule:  `{"objectID": "a", "conditions": [{"pattern": "x"}], "consequence": {"filterPromotes": true}}`,
+			paths: []string{"/conditions/0/anchoring"},
+		},
+		{
+			name:  "empty consequence and validity",
+			rule:  `{"objectID": "a", "consequence": {}, "validity": [{"from": 2, "until": 1}]}`,
+			paths: []string{"/consequence", "/validity/0/until"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rule map[string]any
+			if err := json.Unmarshal([]byte(tt.rule), &rule); err != nil {
+				t.Fatal(err)
+			}
+			errs, err := validateRule(rule)
+			if err != nil {
+				t.Fatalf("validateRule: %v", err)
+			}
+			var paths []string
+			for _, e := range errs {
+				paths = append(paths, e.Path)
+			}
+			if !reflect.DeepEqual(paths, tt.paths) {
+				t.Errorf("error paths = %q, want %q (errors: %v)", paths, tt.paths, errs)
+			}
+		})
+	}
+}
@@ -3,8 +3,13 @@ package search
 import (
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/search/indices"
+	"github.com/algolia/mcp/pkg/search/lint"
+	"github.com/algolia/mcp/pkg/search/logs"
 	"github.com/algolia/mcp/pkg/search/query"
 	"github.com/algolia/mcp/pkg/search/records"
+	"github.com/algolia/mcp/pkg/search/relevance"
+	"github.com/algolia/mcp/pkg/search/rules"
+	"github.com/algolia/mcp/pkg/search/synonyms"
 	"github.com/mark3labs/mcp-go/server"
 )
 
@@ -19,20 +24,47 @@ func RegisterAll(mcps *server.MCPServer, readClient *search.Client, writeClient
 func RegisterReadAll(mcps *server.MCPServer, client *search.Client, index *search.Index) {
 	// Register read-only operations.
 	indices.RegisterList(mcps, client)
-	indices.RegisterGetSettings(mcps, index)
+	indices.RegisterInventory(mcps, client)
+	indices.RegisterGetSettings(mcps, client, index)
+	indices.RegisterDiffSettings(mcps, client, index)
+	logs.RegisterGetLogs(mcps, client)
 	query.RegisterRunQuery(mcps, client, index)
-	records.RegisterGetObject(mcps, index)
+	query.RegisterInvestigateNoResults(mcps, client, index)
+	records.RegisterGetObject(mcps, client, index)
+	records.RegisterInferSchema(mcps, client, index)
+	lint.RegisterLintSettings(mcps, client, index)
+	relevance.RegisterRunSuite(mcps, client, index)
+	rules.RegisterSearchRules(mcps, client, index)
+	rules.RegisterExportRules(mcps, client, index)
+	rules.RegisterRulesCalendar(mcps, client, index)
+	synonyms.RegisterSearchSynonym(mcps, client, index)
+	synonyms.RegisterGetSynonym(mcps, client, index)
+	synonyms.RegisterExportSynonyms(mcps, client, index)
+	synonyms.RegisterSuggestSynonyms(mcps, client, index)
 }
 
 // RegisterWriteAll registers write-only Search tools with the MCP server.
 func RegisterWriteAll(mcps *server.MCPServer, client *search.Client, index *search.Index) {
 	// Register write operations.
-	indices.RegisterClear(mcps, index)
+	indices.RegisterClear(mcps, client, index)
 	indices.RegisterCopy(mcps, client, index)
-	indices.RegisterDelete(mcps, index)
+	indices.RegisterCopyAcrossApps(mcps, client, index)
+	indices.RegisterCreateReplica(mcps, client, index)
+	indices.RegisterDelete(mcps, client, index)
+	indices.RegisterDeleteReplica(mcps, client, index)
 	indices.RegisterMove(mcps, client, index)
-	indices.RegisterSetSettings(mcps, index)
-	records.RegisterDeleteObject(mcps, index)
-	records.RegisterInsertObject(mcps, index)
-	records.RegisterInsertObjects(mcps, index)
+	indices.RegisterSetSettings(mcps, client, index)
+	indices.RegisterPromoteSettings(mcps, client, index)
+	indices.RegisterSnapshot(mcps, client, index)
+	indices.RegisterRestore(mcps, client, index)
+	records.RegisterDeleteObject(mcps, client, index)
+	records.RegisterInsertObject(mcps, client, index)
+	records.RegisterInsertObjects(mcps, client, index)
+	rules.RegisterDeleteRule(mcps, client, index)
+	rules.RegisterSaveRule(mcps, client, index)
+	rules.RegisterImportRules(mcps, client, index)
+	synonyms.RegisterInsertSynonym(mcps, client, index)
+	synonyms.RegisterDeleteSynonym(mcps, client, index)
+	synonyms.RegisterClearSynonyms(mcps, client, index)
+	synonyms.RegisterImportSynonyms(mcps, client, index)
 }
@@ -0,0 +1,28 @@
+package searchutil
+
+import (
+	"strings"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+)
+
+// SearchableAttributeNames returns the names of the searchable attributes of
+// an index, with their modifiers stripped and equal-priority entries split.
+func SearchableAttributeNames(settings search.Settings) []string {
+	var names []string
+	for _, entry := range settings.SearchableAttributes.Get() {
+		for _, a := range strings.Split(entry, ",") {
+			names = append(names, UnwrapModifier(strings.TrimSpace(a)))
+		}
+	}
+	return names
+}
+
+// UnwrapModifier strips modifiers such as "filterOnly(...)", "unordered(...)"
+// or "desc(...)" from an attribute name.
+func UnwrapModifier(a string) string {
+	if i := strings.Index(a, "("); i >= 0 && strings.HasSuffix(a, ")") {
+		return a[i+1 : len(a)-1]
+	}
+	return a
+}
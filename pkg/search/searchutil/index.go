@@ -0,0 +1,29 @@
+package searchutil
+
+import (
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// WithIndexName adds the optional indexName argument shared by the Search
+// tools, which selects the index a call operates on.
+func WithIndexName() mcp.ToolOption {
+	return mcp.WithString(
+		"indexName",
+		mcp.Description("The index to operate on (defaults to the configured index)"),
+	)
+}
+
+// Index returns the index named by the indexName argument of a request, or
+// the default index when the argument is missing or empty.
+func Index(client *search.Client, def *search.Index, req mcp.CallToolRequest) *search.Index {
+	return IndexFromArg(client, def, req, "indexName")
+}
+
+// IndexFromArg is like Index, but reads the index name from the given argument.
+func IndexFromArg(client *search.Client, def *search.Index, req mcp.CallToolRequest, arg string) *search.Index {
+	if name, ok := req.Params.Arguments[arg].(string); ok && name != "" {
+		return client.InitIndex(name)
+	}
+	return def
+}
@@ -0,0 +1,341 @@
+package searchutil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+)
+
+// ObjectsDiff lists how imported rules or synonyms differ from an index.
+type ObjectsDiff struct {
+	Added     []string `json:"added"`
+	Changed   []string `json:"changed"`
+	Unchanged int      `json:"unchanged"`
+	Removed   []string `json:"removed"`
+}
+
+// DiffObjects compares imported objects with the current ones, by objectID.
+// Removed objects are only reported when the import replaces everything.
+func DiffObjects(current, incoming map[string]map[string]any, replace bool) ObjectsDiff {
+	d := ObjectsDiff{Added: []string{}, Changed: []string{}, Removed: []string{}}
+	for _, id := range sortedIDs(incoming) {
+		cur, ok := current[id]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, id)
+		case !reflect.DeepEqual(cur, incoming[id]):
+			d.Changed = append(d.Changed, id)
+		default:
+			d.Unchanged++
+		}
+	}
+	if replace {
+		for _, id := range sortedIDs(current) {
+			if _, ok := incoming[id]; !ok {
+				d.Removed = append(d.Removed, id)
+			}
+		}
+	}
+	return d
+}
+
+// GetAllRules browses every rule of an index, keyed by objectID.
+func GetAllRules(index *search.Index) (map[string]search.Rule, error) {
+	it, err := index.BrowseRules()
+	if err != nil {
+		return nil, err
+	}
+	rules := make(map[string]search.Rule)
+	for {
+		rule, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			return rules, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rules[rule.ObjectID] = *rule
+	}
+}
+
+// GetAllSynonyms browses every synonym of an index, keyed by objectID.
+func GetAllSynonyms(index *search.Index) (map[string]search.Synonym, error) {
+	it, err := index.BrowseSynonyms()
+	if err != nil {
+		return nil, err
+	}
+	synonyms := make(map[string]search.Synonym)
+	for {
+		synonym, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			return synonyms, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		synonyms[synonym.ObjectID()] = synonym
+	}
+}
+
+// GetAllObjects returns every rule or synonym (kind) of an index as JSON
+// objects, sorted by objectID.
+func GetAllObjects(index *search.Index, kind string) ([]map[string]any, error) {
+	switch kind {
+	case "rules":
+		rules, err := GetAllRules(index)
+		if err != nil {
+			return nil, err
+		}
+		return toObjects(rules)
+	case "synonyms":
+		synonyms, err := GetAllSynonyms(index)
+		if err != nil {
+			return nil, err
+		}
+		return toObjects(synonyms)
+	default:
+		return nil, fmt.Errorf("unknown kind %s", kind)
+	}
+}
+
+// toObjects converts typed rules or synonyms to JSON objects, sorted by
+// objectID.
+func toObjects[T any](m map[string]T) ([]map[string]any, error) {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	objects := make([]map[string]any, 0, len(ids))
+	for _, id := range ids {
+		b, err := json.Marshal(m[id])
+		if err != nil {
+			return nil, err
+		}
+		var o map[string]any
+		if err := json.Unmarshal(b, &o); err != nil {
+			return nil, err
+		}
+		objects = append(objects, o)
+	}
+	return objects, nil
+}
+
+// normalizeRules round-trips imported rules through the client type, so they
+// compare equal to the rules returned by GetAllObjects when only their
+// encoding differs, e.g. automaticFacetFilters given as plain strings.
+func normalizeRules(objects map[string]map[string]any) (map[string]map[string]any, error) {
+	rules := make(map[string]search.Rule, len(objects))
+	for id, o := range objects {
+		b, err := json.Marshal(o)
+		if err != nil {
+			return nil, err
+		}
+		var r search.Rule
+		if err := json.Unmarshal(b, &r); err != nil {
+			return nil, fmt.Errorf("invalid rule %s: %w", id, err)
+		}
+		rules[id] = r
+	}
+	list, err := toObjects(rules)
+	if err != nil {
+		return nil, err
+	}
+	return KeyByObjectID(list)
+}
+
+// normalizeSynonyms round-trips imported synonyms through the client types,
+// like normalizeRules, so that e.g. a type given as "onewaysynonym" compares
+// equal to the "oneWaySynonym" returned by GetAllObjects.
+func normalizeSynonyms(objects map[string]map[string]any) (map[string]map[string]any, error) {
+	hits := make([]map[string]any, 0, len(objects))
+	for _, id := range sortedIDs(objects) {
+		hits = append(hits, objects[id])
+	}
+	list, err := search.SearchSynonymsRes{Hits: hits}.Synonyms()
+	if err != nil {
+		return nil, fmt.Errorf("invalid synonyms: %w", err)
+	}
+	synonyms := make(map[string]search.Synonym, len(list))
+	for _, s := range list {
+		synonyms[s.ObjectID()] = s
+	}
+	objs, err := toObjects(synonyms)
+	if err != nil {
+		return nil, err
+	}
+	return KeyByObjectID(objs)
+}
+
+// FileFormat returns the format of an import or export file, "csv" or
+// "json", from the format argument or the file extension.
+func FileFormat(path, format string) (string, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	switch format {
+	case "csv", "json":
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown format for %s: set format to csv or json", path)
+	}
+}
+
+// ReadJSONObjects reads a JSON array of objects, as exported by the dashboard.
+func ReadJSONObjects(path string) ([]map[string]any, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var objects []map[string]any
+	if err := json.Unmarshal(b, &objects); err != nil {
+		return nil, fmt.Errorf("invalid JSON in %s: %w", path, err)
+	}
+	return objects, nil
+}
+
+// WriteJSONObjects writes objects as a JSON array, the dashboard export format.
+func WriteJSONObjects(path string, objects []map[string]any) error {
+	b, err := json.MarshalIndent(objects, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+// KeyByObjectID indexes objects by objectID, and fails on missing or
+// duplicate objectIDs.
+func KeyByObjectID(objects []map[string]any) (map[string]map[string]any, error) {
+	m := make(map[string]map[string]any, len(objects))
+	for i, o := range objects {
+		id, _ := o["objectID"].(string)
+		if id == "" {
+			return nil, fmt.Errorf("object at index %d has no objectID", i)
+		}
+		if _, ok := m[id]; ok {
+			return nil, fmt.Errorf("duplicate objectID %s", id)
+		}
+		m[id] = o
+	}
+	return m, nil
+}
+
+// SaveObjectsBatch saves rules or synonyms (kind) with their batch endpoint.
+// When replace is true, the objects missing from the batch are deleted in the
+// same atomic operation.
+func SaveObjectsBatch(client *search.Client, indexName, kind string, objects []map[string]any, replace, forwardToReplicas bool) (search.UpdateTaskRes, error) {
+	var res search.UpdateTaskRes
+	params := map[string]string{"forwardToReplicas": strconv.FormatBool(forwardToReplicas)}
+	switch kind {
+	case "rules":
+		params["clearExistingRules"] = strconv.FormatBool(replace)
+	case "synonyms":
+		params["replaceExistingSynonyms"] = strconv.FormatBool(replace)
+	default:
+		return res, fmt.Errorf("unknown kind %s", kind)
+	}
+	if objects == nil {
+		objects = []map[string]any{}
+	}
+	path := fmt.Sprintf("/1/indexes/%s/%s/batch", url.PathEscape(indexName), kind)
+	err := client.CustomRequest(&res, http.MethodPost, path, objects, call.Write, opt.ExtraURLParams(params))
+	return res, err
+}
+
+func sortedIDs(m map[string]map[string]any) []string {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// ImportResult is the outcome of an import of rules or synonyms.
+type ImportResult struct {
+	IndexName string                `json:"indexName"`
+	Path      string                `json:"path"`
+	Format    string                `json:"format"`
+	Mode      string                `json:"mode"`
+	Count     int                   `json:"count"`
+	Applied   bool                  `json:"applied"`
+	Diff      ObjectsDiff           `json:"diff"`
+	Task      *search.UpdateTaskRes `json:"task,omitempty"`
+}
+
+// Import modes.
+const (
+	ImportMerge   = "merge"
+	ImportReplace = "replace"
+)
+
+// ImportObjects diffs imported rules or synonyms (kind) against an index, and
+// saves them when apply is true. In merge mode only the added and changed
+// objects are sent, in replace mode the objects missing from the import are
+// deleted too.
+func ImportObjects(client *search.Client, res *ImportResult, kind string, objects []map[string]any, apply, forwardToReplicas bool) error {
+	incoming, err := KeyByObjectID(objects)
+	if err != nil {
+		return err
+	}
+	currentList, err := GetAllObjects(client.InitIndex(res.IndexName), kind)
+	if err != nil {
+		return fmt.Errorf("could not get the current %s: %w", kind, err)
+	}
+	current, err := KeyByObjectID(currentList)
+	if err != nil {
+		return err
+	}
+	var compared map[string]map[string]any
+	switch kind {
+	case "rules":
+		compared, err = normalizeRules(incoming)
+	case "synonyms":
+		compared, err = normalizeSynonyms(incoming)
+	default:
+		compared = incoming
+	}
+	if err != nil {
+		return err
+	}
+
+	replace := res.Mode == ImportReplace
+	res.Count = len(objects)
+	res.Diff = DiffObjects(current, compared, replace)
+	if !apply {
+		return nil
+	}
+
+	batch := objects
+	if !replace {
+		batch = make([]map[string]any, 0, len(res.Diff.Added)+len(res.Diff.Changed))
+		for _, id := range append(slices.Clone(res.Diff.Added), res.Diff.Changed...) {
+			batch = append(batch, incoming[id])
+		}
+		if len(batch) == 0 {
+			res.Applied = true
+			return nil
+		}
+	}
+	task, err := SaveObjectsBatch(client, res.IndexName, kind, batch, replace, forwardToReplicas)
+	if err != nil {
+		return fmt.Errorf("could not save %s: %w", kind, err)
+	}
+	res.Applied = true
+	res.Task = &task
+	return nil
+}
@@ -0,0 +1,58 @@
+package searchutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+)
+
+// Profile holds the credentials of an Algolia application.
+type Profile struct {
+	AppID  string `json:"appID"`
+	APIKey string `json:"apiKey"`
+}
+
+// profilesFile returns the path of the file declaring the named profiles.
+func profilesFile() (string, error) {
+	if path := os.Getenv("MCP_PROFILES_FILE"); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("no profiles file: set MCP_PROFILES_FILE")
+	}
+	return filepath.Join(home, ".algolia-mcp", "profiles.json"), nil
+}
+
+// LoadProfile returns a named profile, declared in the MCP_PROFILES_FILE
+// JSON file as {"<name>": {"appID": "...", "apiKey": "..."}}.
+func LoadProfile(name string) (Profile, error) {
+	path, err := profilesFile()
+	if err != nil {
+		return Profile{}, err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Profile{}, fmt.Errorf("could not read profiles: %w", err)
+	}
+	var profiles map[string]Profile
+	if err := json.Unmarshal(b, &profiles); err != nil {
+		return Profile{}, fmt.Errorf("invalid profiles file %s: %w", path, err)
+	}
+	p, ok := profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("unknown profile %q in %s", name, path)
+	}
+	if p.AppID == "" || p.APIKey == "" {
+		return Profile{}, fmt.Errorf("profile %q must set appID and apiKey", name)
+	}
+	return p, nil
+}
+
+// Client returns a client for the application of the profile.
+func (p Profile) Client() *search.Client {
+	return search.NewClient(p.AppID, p.APIKey)
+}
@@ -0,0 +1,115 @@
+package searchutil
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+)
+
+// GetRawSettings returns the settings of an index as a JSON object, so that
+// settings unknown to the client are diffed and copied too.
+func GetRawSettings(client *search.Client, indexName string) (map[string]any, error) {
+	var settings map[string]any
+	path := fmt.Sprintf("/1/indexes/%s/settings", url.PathEscape(indexName))
+	err := client.CustomRequest(&settings, http.MethodGet, path, nil, call.Read,
+		opt.ExtraURLParams(map[string]string{"getVersion": "2"}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// SetRawSettings applies a partial settings object to an index. Keys with a
+// nil value are reset to their default.
+func SetRawSettings(client *search.Client, indexName string, settings map[string]any, forwardToReplicas bool) (search.UpdateTaskRes, error) {
+	var res search.UpdateTaskRes
+	path := fmt.Sprintf("/1/indexes/%s/settings", url.PathEscape(indexName))
+	err := client.CustomRequest(&res, http.MethodPut, path, settings, call.Write,
+		opt.ForwardToReplicas(forwardToReplicas),
+	)
+	return res, err
+}
+
+// GetReplicas returns the replicas declared in the settings of a primary
+// index, virtual replicas included as "virtual(name)".
+func GetReplicas(settings map[string]any) []string {
+	raw, _ := settings["replicas"].([]any)
+	replicas := make([]string, 0, len(raw))
+	for _, r := range raw {
+		if s, ok := r.(string); ok {
+			replicas = append(replicas, s)
+		}
+	}
+	return replicas
+}
+
+// ReplicaName strips the "virtual(...)" wrapper from a replicas entry.
+func ReplicaName(entry string) string {
+	if strings.HasPrefix(entry, "virtual(") && strings.HasSuffix(entry, ")") {
+		return entry[len("virtual(") : len(entry)-1]
+	}
+	return entry
+}
+
+// Kinds of change reported by a diff.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// UnpromotedSettings are the settings left out of a promotion unless
+// explicitly requested, because they describe the index topology or the
+// settings format rather than the relevance configuration.
+var UnpromotedSettings = []string{"replicas", "primary", "version"}
+
+// SettingChange is a single setting that differs between a source and a
+// target index.
+type SettingChange struct {
+	Key    string `json:"key"`
+	Change string `json:"change"`
+	Source any    `json:"source,omitempty"`
+	Target any    `json:"target,omitempty"`
+}
+
+// DiffSettings compares two settings objects key by key. Added settings are
+// only set on the source, removed settings only on the target.
+func DiffSettings(source, target map[string]any) []SettingChange {
+	changes := []SettingChange{}
+	for _, key := range SortedKeys(source, target) {
+		s, inSource := source[key]
+		t, inTarget := target[key]
+		switch {
+		case inSource && !inTarget:
+			changes = append(changes, SettingChange{Key: key, Change: ChangeAdded, Source: s})
+		case !inSource && inTarget:
+			changes = append(changes, SettingChange{Key: key, Change: ChangeRemoved, Target: t})
+		case !reflect.DeepEqual(s, t):
+			changes = append(changes, SettingChange{Key: key, Change: ChangeChanged, Source: s, Target: t})
+		}
+	}
+	return changes
+}
+
+// SortedKeys returns the keys of two maps, sorted and without duplicates.
+func SortedKeys[T any](a, b map[string]T) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,185 @@
+package synonyms
+
+import (
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// CSV layout of synonyms. The first row is a header naming the columns, in
+// any order. List cells hold values separated by listSeparator. The columns
+// used depend on the synonym type:
+//
+//	synonym         synonyms
+//	oneWaySynonym   input, synonyms
+//	altCorrection1  word, corrections
+//	altCorrection2  word, corrections
+//	placeholder     placeholder, replacements
+//
+// Types are case-insensitive, as in the API, so "onewaysynonym" is accepted.
+// When objectID is empty, a stable one is derived from the content of the row.
+var csvColumns = []string{"objectID", "type", "synonyms", "input", "word", "corrections", "placeholder", "replacements"}
+
+const listSeparator = ";"
+
+// listColumns are the columns holding lists.
+var listColumns = []string{"synonyms", "corrections", "replacements"}
+
+// typeColumns are the columns each synonym type requires.
+var typeColumns = map[string][]string{
+	"synonym":        {"synonyms"},
+	"oneWaySynonym":  {"input", "synonyms"},
+	"altCorrection1": {"word", "corrections"},
+	"altCorrection2": {"word", "corrections"},
+	"placeholder":    {"placeholder", "replacements"},
+}
+
+// synonymType returns the name of a synonym type as used in typeColumns,
+// matching it case-insensitively.
+func synonymType(typ string) (string, bool) {
+	for t := range typeColumns {
+		if strings.EqualFold(t, typ) {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// readCSV reads synonyms from a CSV file.
+func readCSV(path string) ([]map[string]any, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("could not read the CSV header: %w", err)
+	}
+	for i, h := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+		if !slices.Contains(csvColumns, header[i]) {
+			return nil, fmt.Errorf("unknown column %q, expected %s", header[i], strings.Join(csvColumns, ", "))
+		}
+	}
+	if !slices.Contains(header, "type") {
+		return nil, fmt.Errorf("missing column type")
+	}
+
+	var synonyms []map[string]any
+	for line := 2; ; line++ {
+		row, err := r.Read()
+		if err == io.EOF {
+			return synonyms, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		s, err := rowToSynonym(header, row)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if s != nil {
+			synonyms = append(synonyms, s)
+		}
+	}
+}
+
+func rowToSynonym(header, row []string) (map[string]any, error) {
+	cells := map[string]string{}
+	empty := true
+	for i, v := range row {
+		if i < len(header) {
+			cells[header[i]] = strings.TrimSpace(v)
+			empty = empty && cells[header[i]] == ""
+		}
+	}
+	if empty {
+		return nil, nil
+	}
+
+	typ, ok := synonymType(cells["type"])
+	if !ok {
+		return nil, fmt.Errorf("unknown synonym type %q", cells["type"])
+	}
+	required := typeColumns[typ]
+	s := map[string]any{"type": typ}
+	for _, col := range required {
+		v := cells[col]
+		if v == "" {
+			return nil, fmt.Errorf("column %s is required for type %s", col, typ)
+		}
+		if slices.Contains(listColumns, col) {
+			var list []any
+			for _, item := range strings.Split(v, listSeparator) {
+				if item = strings.TrimSpace(item); item != "" {
+					list = append(list, item)
+				}
+			}
+			s[col] = list
+		} else {
+			s[col] = v
+		}
+	}
+
+	if id := cells["objectID"]; id != "" {
+		s["objectID"] = id
+	} else {
+		s["objectID"] = generatedObjectID(typ, row)
+	}
+	return s, nil
+}
+
+func generatedObjectID(typ string, row []string) string {
+	h := sha1.Sum([]byte(strings.Join(row, "\x00")))
+	return typ + "-" + hex.EncodeToString(h[:])[:12]
+}
+
+// writeCSV writes synonyms to a CSV file.
+func writeCSV(path string, synonyms []map[string]any) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write(csvColumns); err != nil {
+		return err
+	}
+	for _, s := range synonyms {
+		row := make([]string, len(csvColumns))
+		for i, col := range csvColumns {
+			switch v := s[col].(type) {
+			case string:
+				row[i] = v
+			case []any:
+				items := make([]string, 0, len(v))
+				for _, item := range v {
+					str := fmt.Sprint(item)
+					if strings.Contains(str, listSeparator) {
+						return fmt.Errorf("synonym %v: %q contains %q, export it as JSON instead", s["objectID"], str, listSeparator)
+					}
+					items = append(items, str)
+				}
+				row[i] = strings.Join(items, listSeparator)
+			}
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return f.Close()
+}
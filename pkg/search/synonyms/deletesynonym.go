@@ -9,9 +9,10 @@ import (
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
 )
 
-func RegisterDeleteSynonym(mcps *server.MCPServer, index *search.Index) {
+func RegisterDeleteSynonym(mcps *server.MCPServer, client *search.Client, index *search.Index) {
 	DeleteSynonymTool := mcp.NewTool(
 		"delete_synonym",
 		mcp.WithDescription("Delete a synonym by its object ID"),
@@ -20,6 +21,7 @@ func RegisterDeleteSynonym(mcps *server.MCPServer, index *search.Index) {
 			mcp.Description("The object ID to delete"),
 			mcp.Required(),
 		),
+		searchutil.WithIndexName(),
 	)
 
 	mcps.AddTool(DeleteSynonymTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -28,7 +30,7 @@ func RegisterDeleteSynonym(mcps *server.MCPServer, index *search.Index) {
 			return mcp.NewToolResultError("invalid object format, expected JSON string"), nil
 		}
 
-		resp, err := index.DeleteSynonym(objectID)
+		resp, err := searchutil.Index(client, index, req).DeleteSynonym(objectID)
 		if err != nil {
 			return nil, fmt.Errorf("could not delete synonyms: %w", err)
 		}
@@ -0,0 +1,62 @@
+package synonyms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+)
+
+func RegisterExportSynonyms(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+	exportSynonymsTool := mcp.NewTool(
+		"export_synonyms",
+		mcp.WithDescription("Export every synonym of an index to a local CSV file, with one row per synonym and the columns objectID, type, synonyms, input, word, corrections, placeholder and replacements (lists are separated by ';'), or to a JSON file in the dashboard export format"),
+		mcp.WithString(
+			"path",
+			mcp.Description("Path of the file to write"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"format",
+			mcp.Description("File format (defaults to the file extension)"),
+			mcp.Enum("csv", "json"),
+		),
+		searchutil.WithIndexName(),
+	)
+
+	mcps.AddTool(exportSynonymsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		path, _ := req.Params.Arguments["path"].(string)
+		formatArg, _ := req.Params.Arguments["format"].(string)
+		format, err := searchutil.FileFormat(path, formatArg)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		indexName := searchutil.Index(client, index, req).GetName()
+
+		synonyms, err := searchutil.GetAllObjects(client.InitIndex(indexName), "synonyms")
+		if err != nil {
+			return nil, fmt.Errorf("could not get synonyms: %w", err)
+		}
+
+		if format == "csv" {
+			err = writeCSV(path, synonyms)
+		} else {
+			err = searchutil.WriteJSONObjects(path, synonyms)
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not write %s: %v", path, err)), nil
+		}
+
+		return mcputil.JSONToolResult("export result", map[string]any{
+			"indexName": indexName,
+			"path":      path,
+			"format":    format,
+			"count":     len(synonyms),
+		})
+	})
+}
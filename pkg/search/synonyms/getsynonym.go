@@ -6,11 +6,12 @@ import (
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
-func RegisterGetSynonym(mcps *server.MCPServer, index *search.Index) {
+func RegisterGetSynonym(mcps *server.MCPServer, client *search.Client, index *search.Index) {
 	getSynonymTool := mcp.NewTool(
 		"get_synonym",
 		mcp.WithDescription("Get a synonym from the Algolia index by its ID"),
@@ -19,6 +20,7 @@ func RegisterGetSynonym(mcps *server.MCPServer, index *search.Index) {
 			mcp.Description("The unique identifier of the synonym to retrieve"),
 			mcp.Required(),
 		),
+		searchutil.WithIndexName(),
 	)
 
 	mcps.AddTool(getSynonymTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -27,7 +29,7 @@ func RegisterGetSynonym(mcps *server.MCPServer, index *search.Index) {
 			return mcp.NewToolResultError("invalid objectID format"), nil
 		}
 
-		synonym, err := index.GetSynonym(objectID)
+		synonym, err := searchutil.Index(client, index, req).GetSynonym(objectID)
 		if err != nil {
 			return nil, fmt.Errorf("could not get synonym: %w", err)
 		}
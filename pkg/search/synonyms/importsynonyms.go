@@ -0,0 +1,115 @@
+package synonyms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+)
+
+func RegisterImportSynonyms(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+	importSynonymsTool := mcp.NewTool(
+		"import_synonyms",
+		mcp.WithDescription("Import synonyms from a local CSV file (same layout as export_synonyms) or JSON file (dashboard export format). Returns the added, changed and removed synonyms, and only saves them when apply is true"),
+		mcp.WithString(
+			"path",
+			mcp.Description("Path of the file to read"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"format",
+			mcp.Description("File format (defaults to the file extension)"),
+			mcp.Enum("csv", "json"),
+		),
+		mcp.WithString(
+			"mode",
+			mcp.Description("merge adds and updates the imported synonyms and keeps the others, replace also deletes the synonyms missing from the file (default: merge)"),
+			mcp.Enum(searchutil.ImportMerge, searchutil.ImportReplace),
+		),
+		mcp.WithBoolean(
+			"apply",
+			mcp.Description("Save the synonyms. When false or omitted, only the diff is returned"),
+		),
+		mcp.WithBoolean(
+			"forwardToReplicas",
+			mcp.Description("Whether to also import the synonyms to the replicas of the index"),
+		),
+		searchutil.WithIndexName(),
+	)
+
+	mcps.AddTool(importSynonymsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if index == nil {
+			return mcp.NewToolResultError("write API key not set, cannot import synonyms"), nil
+		}
+		path, _ := req.Params.Arguments["path"].(string)
+		formatArg, _ := req.Params.Arguments["format"].(string)
+		mode, _ := req.Params.Arguments["mode"].(string)
+		if mode == "" {
+			mode = searchutil.ImportMerge
+		}
+		apply, _ := req.Params.Arguments["apply"].(bool)
+		forwardToReplicas, _ := req.Params.Arguments["forwardToReplicas"].(bool)
+
+		format, err := searchutil.FileFormat(path, formatArg)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		var synonyms []map[string]any
+		if format == "csv" {
+			synonyms, err = readCSV(path)
+		} else {
+			synonyms, err = searchutil.ReadJSONObjects(path)
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("could not read %s: %v", path, err)), nil
+		}
+		for i, s := range synonyms {
+			if err := validateSynonym(s); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("synonym at index %d: %v", i, err)), nil
+			}
+		}
+
+		res := searchutil.ImportResult{
+			IndexName: searchutil.Index(client, index, req).GetName(),
+			Path:      path,
+			Format:    format,
+			Mode:      mode,
+		}
+		if err := searchutil.ImportObjects(client, &res, "synonyms", synonyms, apply, forwardToReplicas); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		return mcputil.JSONToolResult("import result", res)
+	})
+}
+
+// validateSynonym checks that a synonym has the fields its type requires,
+// and spells its type as the API returns it.
+func validateSynonym(s map[string]any) error {
+	raw, _ := s["type"].(string)
+	typ, ok := synonymType(raw)
+	if !ok {
+		return fmt.Errorf("unknown synonym type %q", raw)
+	}
+	s["type"] = typ
+	required := typeColumns[typ]
+	for _, field := range required {
+		switch v := s[field].(type) {
+		case string:
+			if v != "" {
+				continue
+			}
+		case []any:
+			if len(v) > 0 {
+				continue
+			}
+		}
+		return fmt.Errorf("%s synonym %v requires %s", typ, s["objectID"], field)
+	}
+	return nil
+}
@@ -1,24 +1,22 @@
 package synonyms
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
 )
 
-const (
-	synonymsBaseURL = "https://%s.algolia.net/1/indexes/%s/synonyms/%s"
-)
-
-func RegisterInsertSynonym(mcps *server.MCPServer, index *search.Index, appID, apiKey string) {
+func RegisterInsertSynonym(mcps *server.MCPServer, client *search.Client, index *search.Index) {
 	insertSynonymTool := mcp.NewTool(
 		"save_synonym",
 		mcp.WithDescription("Save or update a synonym in the Algolia index"),
@@ -32,10 +30,14 @@ func RegisterInsertSynonym(mcps *server.MCPServer, index *search.Index, appID, a
 			mcp.Description("The synonym object as a JSON string. Example schema: {\"objectID\":\"unique_id\",\"type\":\"synonym\",\"synonyms\":[\"word1\",\"word2\",\"word3\"]} or {\"objectID\":\"unique_id\",\"type\":\"oneWaySynonym\",\"input\":\"word1\",\"synonyms\":[\"word2\",\"word3\"]} or {\"objectID\":\"unique_id\",\"type\":\"altCorrection1\",\"word\":\"word1\",\"corrections\":[\"word2\",\"word3\"]} or {\"objectID\":\"unique_id\",\"type\":\"altCorrection2\",\"word\":\"word1\",\"corrections\":[\"word2\",\"word3\"]} or {\"objectID\":\"unique_id\",\"type\":\"placeholder\",\"placeholder\":\"<em>`,\"replacements\":[\"word1\",\"word2\"]}"),
 			mcp.Required(),
 		),
+		searchutil.WithIndexName(),
 	)
 
 	mcps.AddTool(insertSynonymTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		indexName := index.GetName()
+		if index == nil {
+			return mcp.NewToolResultError("write API key not set, cannot save a synonym"), nil
+		}
+		indexName := searchutil.Index(client, index, req).GetName()
 		objectID, ok := req.Params.Arguments["objectID"].(string)
 		if !ok {
 			return mcp.NewToolResultError("invalid objectID format"), nil
@@ -46,50 +48,22 @@ func RegisterInsertSynonym(mcps *server.MCPServer, index *search.Index, appID, a
 			return mcp.NewToolResultError("invalid synonym format"), nil
 		}
 
-		// Parse synonym
-		synonym := struct {
-			ObjectID string `json:"objectID"`
-			Synonym  string `json:"synonym"`
-		}{}
+		// The synonym is sent as is, so every synonym type is supported.
+		var synonym map[string]any
 		if err := json.Unmarshal([]byte(synonymStr), &synonym); err != nil {
-			return nil, fmt.Errorf("could not unmarshal synonym: %w", err)
+			return mcp.NewToolResultError(fmt.Sprintf("could not unmarshal synonym: %v", err)), nil
 		}
-
-		// Create HTTP client
-		httpClient := &http.Client{}
-
-		// Build request URL
-		url := fmt.Sprintf(synonymsBaseURL, appID, indexName, objectID)
-
-		// Create request
-		httpReq, err := http.NewRequest(http.MethodPut, url, bytes.NewBuffer([]byte(synonymStr)))
-		if err != nil {
-			return nil, fmt.Errorf("could not create request: %w", err)
-		}
-
-		// Add headers
-		httpReq.Header.Set("Content-Type", "application/json")
-		httpReq.Header.Set("X-Algolia-Application-Id", appID)
-		httpReq.Header.Set("X-Algolia-API-Key", apiKey)
-
-		// Send request
-		resp, err := httpClient.Do(httpReq)
-		if err != nil {
-			return nil, fmt.Errorf("could not send request: %w", err)
-		}
-		defer resp.Body.Close()
-
-		// Check response status
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("request failed with status: %d", resp.StatusCode)
+		if id, ok := synonym["objectID"]; ok && id != objectID {
+			return mcp.NewToolResultError(fmt.Sprintf("synonym objectID %v does not match %s", id, objectID)), nil
 		}
+		synonym["objectID"] = objectID
 
-		// Parse response
-		var result interface{}
-		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-			return nil, fmt.Errorf("could not decode response: %w", err)
+		var res search.UpdateTaskRes
+		path := fmt.Sprintf("/1/indexes/%s/synonyms/%s", url.PathEscape(indexName), url.PathEscape(objectID))
+		if err := client.CustomRequest(&res, http.MethodPut, path, synonym, call.Write); err != nil {
+			return nil, fmt.Errorf("could not save synonym: %w", err)
 		}
 
-		return mcputil.JSONToolResult("task", result)
+		return mcputil.JSONToolResult("task", res)
 	})
 }
@@ -9,9 +9,10 @@ import (
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
 )
 
-func RegisterSearchSynonym(mcps *server.MCPServer, index *search.Index) {
+func RegisterSearchSynonym(mcps *server.MCPServer, client *search.Client, index *search.Index) {
 	searchSynonymTool := mcp.NewTool(
 		"search_synonyms",
 		mcp.WithDescription("Search for synonyms in the Algolia index that match a query"),
@@ -19,12 +20,13 @@ func RegisterSearchSynonym(mcps *server.MCPServer, index *search.Index) {
 			"query",
 			mcp.Description("The query to find synonyms for"),
 		),
+		searchutil.WithIndexName(),
 	)
 
 	mcps.AddTool(searchSynonymTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		query, _ := req.Params.Arguments["query"].(string)
 
-		resp, err := index.SearchSynonyms(query)
+		resp, err := searchutil.Index(client, index, req).SearchSynonyms(query)
 		if err != nil {
 			return nil, fmt.Errorf("could not search synonyms: %w", err)
 		}
@@ -0,0 +1,359 @@
+package synonyms
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+)
+
+// Vocabulary is the words found in the searchable attributes of a sample of
+// records, with the number of sampled records containing each word.
+type Vocabulary struct {
+	Sampled int            `json:"sampled"`
+	Words   map[string]int `json:"-"`
+}
+
+// SampleVocabulary browses up to size records of an index and collects the
+// words of their searchable attributes, or of every string attribute when
+// searchableAttributes is not set.
+func SampleVocabulary(index *search.Index, size int) (Vocabulary, error) {
+	settings, err := index.GetSettings()
+	if err != nil {
+		return Vocabulary{}, fmt.Errorf("could not get settings: %w", err)
+	}
+	attributes := searchutil.SearchableAttributeNames(settings)
+
+	it, err := index.BrowseObjects(opt.HitsPerPage(min(size, 1000)))
+	if err != nil {
+		return Vocabulary{}, fmt.Errorf("could not browse records: %w", err)
+	}
+	vocab := Vocabulary{Words: map[string]int{}}
+	for vocab.Sampled < size {
+		obj, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Vocabulary{}, fmt.Errorf("could not browse records: %w", err)
+		}
+		record, _ := obj.(map[string]any)
+		vocab.Sampled++
+
+		seen := map[string]bool{}
+		for k, v := range record {
+			if k != "objectID" {
+				collectWords(seen, attributes, k, v)
+			}
+		}
+		for w := range seen {
+			vocab.Words[w]++
+		}
+	}
+	return vocab, nil
+}
+
+// collectWords adds the words of the string values under path to seen, when
+// path is one of the attributes or nested in one. Every path matches when
+// attributes is empty.
+func collectWords(seen map[string]bool, attributes []string, path string, v any) {
+	switch v := v.(type) {
+	case string:
+		if len(attributes) > 0 && !slices.ContainsFunc(attributes, func(a string) bool {
+			return path == a || strings.HasPrefix(path, a+".")
+		}) {
+			return
+		}
+		for _, w := range words(v) {
+			seen[w] = true
+		}
+	case []any:
+		for _, e := range v {
+			collectWords(seen, attributes, path, e)
+		}
+	case map[string]any:
+		for k, e := range v {
+			collectWords(seen, attributes, path+"."+k, e)
+		}
+	}
+}
+
+// words splits a text into lowercase words.
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Candidate reasons, from the most to the least reliable.
+const (
+	ReasonCompound = "compound"
+	ReasonPlural   = "plural"
+	ReasonSpelling = "spelling"
+	ReasonPrefix   = "prefix"
+)
+
+var reasonRank = map[string]int{ReasonCompound: 0, ReasonPlural: 1, ReasonSpelling: 2, ReasonPrefix: 3}
+
+// maxCandidates is the number of candidates kept per search.
+const maxCandidates = 3
+
+// Candidate is a text found in the records that a search may have meant.
+type Candidate struct {
+	Text            string `json:"text"`
+	Reason          string `json:"reason"`
+	Records         int    `json:"records"`
+	ExistingSynonym string `json:"existingSynonym,omitempty"`
+}
+
+// Suggestion statuses.
+const (
+	StatusSuggested = "suggested"
+	StatusCovered   = "covered"
+	StatusUnmatched = "unmatched"
+)
+
+// Suggestion is the synonym proposed for a search without results. Synonym
+// is ready to be saved with save_synonym or import_synonyms.
+type Suggestion struct {
+	Term       string         `json:"term"`
+	Searches   int            `json:"searches"`
+	Status     string         `json:"status"`
+	Reason     string         `json:"reason,omitempty"`
+	Action     string         `json:"action,omitempty"`
+	Synonym    map[string]any `json:"synonym,omitempty"`
+	Candidates []Candidate    `json:"candidates,omitempty"`
+}
+
+// Suggester proposes synonyms for searches from the vocabulary of an index
+// and its existing synonyms.
+type Suggester struct {
+	vocab Vocabulary
+	// byText indexes the existing synonyms by the texts they contain.
+	byText map[string][]map[string]any
+}
+
+// NewSuggester returns a Suggester for a vocabulary and the existing synonyms.
+func NewSuggester(vocab Vocabulary, existing []map[string]any) *Suggester {
+	s := &Suggester{vocab: vocab, byText: map[string][]map[string]any{}}
+	for _, syn := range existing {
+		var texts []string
+		if input, ok := syn["input"].(string); ok {
+			texts = append(texts, input)
+		}
+		list, _ := syn["synonyms"].([]any)
+		for _, t := range list {
+			if t, ok := t.(string); ok {
+				texts = append(texts, t)
+			}
+		}
+		for _, t := range texts {
+			key := strings.Join(words(t), " ")
+			s.byText[key] = append(s.byText[key], syn)
+		}
+	}
+	return s
+}
+
+// Suggest proposes a synonym for a search without results.
+func (s *Suggester) Suggest(term string, searches int) Suggestion {
+	sug := Suggestion{Term: term, Searches: searches}
+	ws := words(term)
+	key := strings.Join(ws, " ")
+	if len(ws) == 0 {
+		sug.Status = StatusUnmatched
+		sug.Reason = "the search has no words"
+		return sug
+	}
+	if existing := s.byText[key]; len(existing) > 0 {
+		sug.Status = StatusCovered
+		sug.Reason = fmt.Sprintf("already in synonym %v", existing[0]["objectID"])
+		return sug
+	}
+
+	candidates := s.candidates(ws)
+	if len(candidates) == 0 {
+		sug.Status = StatusUnmatched
+		if !slices.ContainsFunc(ws, func(w string) bool { return s.vocab.Words[w] == 0 }) {
+			sug.Reason = "every word occurs in the sampled records, the search may fail on filters or on the combination of words"
+		} else {
+			sug.Reason = "no similar word in the sampled records"
+		}
+		return sug
+	}
+	for i, c := range candidates {
+		for _, syn := range s.byText[c.Text] {
+			if syn["type"] == "synonym" {
+				candidates[i].ExistingSynonym, _ = syn["objectID"].(string)
+				break
+			}
+		}
+	}
+
+	sug.Status = StatusSuggested
+	sug.Candidates = candidates
+	best := candidates[0]
+	switch {
+	case best.ExistingSynonym != "":
+		// Extend the existing group rather than creating an overlapping one.
+		for _, syn := range s.byText[best.Text] {
+			if syn["objectID"] == best.ExistingSynonym {
+				list, _ := syn["synonyms"].([]any)
+				sug.Action = "extend"
+				sug.Synonym = map[string]any{
+					"objectID": syn["objectID"],
+					"type":     "synonym",
+					"synonyms": append(slices.Clone(list), key),
+				}
+				break
+			}
+		}
+	case best.Reason == ReasonCompound || best.Reason == ReasonPlural:
+		// Equivalent forms of the same word work both ways.
+		sug.Action = "create"
+		sug.Synonym = map[string]any{
+			"objectID": "suggested-" + strings.Join(ws, "-"),
+			"type":     "synonym",
+			"synonyms": []any{key, best.Text},
+		}
+	default:
+		targets := []any{}
+		for _, c := range candidates {
+			targets = append(targets, c.Text)
+		}
+		sug.Action = "create"
+		sug.Synonym = map[string]any{
+			"objectID": "suggested-" + strings.Join(ws, "-"),
+			"type":     "oneWaySynonym",
+			"input":    key,
+			"synonyms": targets,
+		}
+	}
+	return sug
+}
+
+// candidates returns the texts of the vocabulary a search may have meant,
+// best first.
+func (s *Suggester) candidates(ws []string) []Candidate {
+	var candidates []Candidate
+	seen := map[string]bool{}
+	add := func(text, reason string, records int) {
+		if !seen[text] {
+			seen[text] = true
+			candidates = append(candidates, Candidate{Text: text, Reason: reason, Records: records})
+		}
+	}
+
+	if len(ws) > 1 {
+		if joined := strings.Join(ws, ""); s.vocab.Words[joined] > 0 {
+			add(joined, ReasonCompound, s.vocab.Words[joined])
+		}
+	} else {
+		w := []rune(ws[0])
+		for i := 2; i <= len(w)-2; i++ {
+			a, b := string(w[:i]), string(w[i:])
+			if s.vocab.Words[a] > 0 && s.vocab.Words[b] > 0 {
+				add(a+" "+b, ReasonCompound, min(s.vocab.Words[a], s.vocab.Words[b]))
+			}
+		}
+	}
+
+	// A compound explains the whole search, word level candidates would only
+	// add noise.
+	compound := len(candidates) > 0
+
+	for i, w := range ws {
+		if compound || s.vocab.Words[w] > 0 {
+			continue
+		}
+		replace := func(v string) string {
+			out := slices.Clone(ws)
+			out[i] = v
+			return strings.Join(out, " ")
+		}
+		for _, v := range pluralForms(w) {
+			if n := s.vocab.Words[v]; n > 0 {
+				add(replace(v), ReasonPlural, n)
+			}
+		}
+		n := len([]rune(w))
+		if n < 4 {
+			continue
+		}
+		maxDist := 1
+		if n >= 8 {
+			maxDist = 2
+		}
+		for v, count := range s.vocab.Words {
+			m := len([]rune(v))
+			switch {
+			case m-n <= maxDist && n-m <= maxDist && levenshtein(w, v) <= maxDist:
+				add(replace(v), ReasonSpelling, count)
+			case m >= 4 && (strings.HasPrefix(v, w) || strings.HasPrefix(w, v)):
+				add(replace(v), ReasonPrefix, count)
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if reasonRank[a.Reason] != reasonRank[b.Reason] {
+			return reasonRank[a.Reason] < reasonRank[b.Reason]
+		}
+		if a.Records != b.Records {
+			return a.Records > b.Records
+		}
+		return a.Text < b.Text
+	})
+	if len(candidates) > maxCandidates {
+		candidates = candidates[:maxCandidates]
+	}
+	return candidates
+}
+
+// pluralForms returns the English singular and plural forms of a word.
+func pluralForms(w string) []string {
+	forms := []string{w + "s", w + "es"}
+	switch {
+	case strings.HasSuffix(w, "ies"):
+		forms = append(forms, strings.TrimSuffix(w, "ies")+"y")
+	case strings.HasSuffix(w, "y"):
+		forms = append(forms, strings.TrimSuffix(w, "y")+"ies")
+	}
+	if strings.HasSuffix(w, "es") {
+		forms = append(forms, strings.TrimSuffix(w, "es"))
+	}
+	if strings.HasSuffix(w, "s") {
+		forms = append(forms, strings.TrimSuffix(w, "s"))
+	}
+	return forms
+}
+
+// levenshtein returns the edit distance between two words.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,127 @@
+package synonyms
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+	vocab := Vocabulary{Sampled: 10, Words: map[string]int{
+		"iphone":     5,
+		"smart":      3,
+		"watch":      4,
+		"headphones": 6,
+		"laptop":     8,
+		"notebook":   2,
+		"case":       7,
+	}}
+	laptops := map[string]any{"objectID": "laptops", "type": "synonym", "synonyms": []any{"laptop", "notebook"}}
+	tests := []struct {
+		name     string
+		term     string
+		existing []map[string]any
+		status   string
+		action   string
+		synonym  map[string]any
+	}{
+		{
+			name:    "joined compound",
+			term:    "I-Phone",
+			status:  StatusSuggested,
+			action:  "create",
+			synonym: map[string]any{"objectID": "suggested-i-phone", "type": "synonym", "synonyms": []any{"i phone", "iphone"}},
+		},
+		{
+			name:    "split compound",
+			term:    "smartwatch",
+			status:  StatusSuggested,
+			action:  "create",
+			synonym: map[string]any{"objectID": "suggested-smartwatch", "type": "synonym", "synonyms": []any{"smartwatch", "smart watch"}},
+		},
+		{
+			name:    "plural",
+			term:    "headphone case",
+			status:  StatusSuggested,
+			action:  "create",
+			synonym: map[string]any{"objectID": "suggested-headphone-case", "type": "synonym", "synonyms": []any{"headphone case", "headphones case"}},
+		},
+		{
+			name:    "spelling",
+			term:    "labtop",
+			status:  StatusSuggested,
+			action:  "create",
+			synonym: map[string]any{"objectID": "suggested-labtop", "type": "oneWaySynonym", "input": "labtop", "synonyms": []any{"laptop"}},
+		},
+		{
+			name:     "extend existing",
+			term:     "labtop",
+			existing: []map[string]any{laptops},
+			status:   StatusSuggested,
+			action:   "extend",
+			synonym:  map[string]any{"objectID": "laptops", "type": "synonym", "synonyms": []any{"laptop", "notebook", "labtop"}},
+		},
+		{
+			name:     "covered",
+			term:     "Notebook",
+			existing: []map[string]any{laptops},
+			status:   StatusCovered,
+		},
+		{
+			name:   "unmatched",
+			term:   "zzzz",
+			status: StatusUnmatched,
+		},
+		{
+			name:   "every word known",
+			term:   "smart case",
+			status: StatusUnmatched,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sug := NewSuggester(vocab, tt.existing).Suggest(tt.term, 1)
+			if sug.Status != tt.status || sug.Action != tt.action {
+				t.Fatalf("suggestion = %s %q, want %s %q (%s)", sug.Status, sug.Action, tt.status, tt.action, sug.Reason)
+			}
+			if !reflect.DeepEqual(sug.Synonym, tt.synonym) {
+				t.Errorf("synonym = %v, want %v", sug.Synonym, tt.synonym)
+			}
+		})
+	}
+}
+
+func TestPluralForms(t *testing.T) {
+	tests := []struct {
+		word string
+		want []string
+	}{
+		{"phone", []string{"phones", "phonees"}},
+		{"battery", []string{"batterys", "batteryes", "batteries"}},
+		{"batteries", []string{"batteriess", "batterieses", "battery", "batteri", "batterie"}},
+		{"boxes", []string{"boxess", "boxeses", "box", "boxe"}},
+	}
+	for _, tt := range tests {
+		if got := pluralForms(tt.word); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("pluralForms(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"laptop", "laptop", 0},
+		{"labtop", "laptop", 1},
+		{"laptop", "laptops", 1},
+		{"kitten", "sitting", 3},
+		{"", "case", 4},
+		{"café", "cafe", 1},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package synonyms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/algolia/mcp/pkg/analytics"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/algolia/mcp/pkg/search/searchutil"
+)
+
+// SuggestResult is the outcome of suggest_synonyms.
+type SuggestResult struct {
+	IndexName   string       `json:"indexName"`
+	Sampled     int          `json:"sampledRecords"`
+	Suggestions []Suggestion `json:"suggestions"`
+	Covered     []Suggestion `json:"covered"`
+	Unmatched   []Suggestion `json:"unmatched"`
+}
+
+func RegisterSuggestSynonyms(mcps *server.MCPServer, client *search.Client, index *search.Index) {
+	suggestSynonymsTool := mcp.NewTool(
+		"suggest_synonyms",
+		mcp.WithDescription("Propose synonyms for the top searches without results of an index, from the analytics API. Each search is matched against the words of a sample of records (compound words, plurals, spelling and prefixes) and the existing synonyms, with the number of searches and records as evidence. Nothing is saved: apply the proposed synonyms with save_synonym or import_synonyms"),
+		mcp.WithString(
+			"startDate",
+			mcp.Description("Start date of the period to analyze, in YYYY-MM-DD format (default: 8 days ago)"),
+		),
+		mcp.WithString(
+			"endDate",
+			mcp.Description("End date of the period to analyze, in YYYY-MM-DD format (default: today)"),
+		),
+		mcp.WithNumber(
+			"limit",
+			mcp.Description("Number of searches without results to analyze (default: 50, max 1000)"),
+		),
+		mcp.WithNumber(
+			"sampleSize",
+			mcp.Description("Number of records sampled to build the vocabulary (default: 1000)"),
+		),
+		searchutil.WithIndexName(),
+	)
+
+	mcps.AddTool(suggestSynonymsTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		startDate, _ := req.Params.Arguments["startDate"].(string)
+		endDate, _ := req.Params.Arguments["endDate"].(string)
+		limit := 50
+		if v, ok := req.Params.Arguments["limit"].(float64); ok && v > 0 {
+			limit = min(int(v), 1000)
+		}
+		sampleSize := 1000
+		if v, ok := req.Params.Arguments["sampleSize"].(float64); ok && v > 0 {
+			sampleSize = int(v)
+		}
+		idx := searchutil.Index(client, index, req)
+
+		searches, err := analytics.GetNoResultSearches(idx.GetName(), startDate, endDate, limit)
+		if err != nil {
+			return nil, fmt.Errorf("could not get searches without results: %w", err)
+		}
+		vocab, err := SampleVocabulary(idx, sampleSize)
+		if err != nil {
+			return nil, err
+		}
+		existing, err := searchutil.GetAllObjects(idx, "synonyms")
+		if err != nil {
+			return nil, fmt.Errorf("could not get synonyms: %w", err)
+		}
+
+		res := SuggestResult{
+			IndexName:   idx.GetName(),
+			Sampled:     vocab.Sampled,
+			Suggestions: []Suggestion{},
+			Covered:     []Suggestion{},
+			Unmatched:   []Suggestion{},
+		}
+		s := NewSuggester(vocab, existing)
+		for _, nr := range searches {
+			sug := s.Suggest(nr.Search, nr.Count)
+			switch sug.Status {
+			case StatusSuggested:
+				res.Suggestions = append(res.Suggestions, sug)
+			case StatusCovered:
+				res.Covered = append(res.Covered, sug)
+			default:
+				res.Unmatched = append(res.Unmatched, sug)
+			}
+		}
+
+		return mcputil.JSONToolResult("synonym suggestions", res)
+	})
+}
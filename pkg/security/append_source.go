@@ -0,0 +1,69 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterAppendSource registers the append_source tool with the MCP server.
+func RegisterAppendSource(mcps *server.MCPServer) {
+	appendSourceTool := mcp.NewTool(
+		"security_append_source",
+		mcp.WithDescription("Add an IP range to the sources allowed to access the application. Returns a before/after preview, and only applies it when apply is true"),
+		mcp.WithString(
+			"source",
+			mcp.Description("IP range in CIDR notation (e.g., '10.0.0.0/24' or '203.0.113.7/32')"),
+			mcp.Required(),
+		),
+		mcp.WithString(
+			"description",
+			mcp.Description("Description of the source (e.g., 'CI egress')"),
+		),
+		mcp.WithBoolean(
+			"apply",
+			mcp.Description("Apply the change. When false or omitted, only the preview is returned"),
+		),
+	)
+
+	mcps.AddTool(appendSourceTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		sourceStr, _ := req.Params.Arguments["source"].(string)
+		source, err := validateCIDR(sourceStr)
+		if err != nil {
+			return nil, err
+		}
+		description, _ := req.Params.Arguments["description"].(string)
+		apply, _ := req.Params.Arguments["apply"].(bool)
+
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		before, err := listSources(client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list sources: %w", err)
+		}
+		entry := Source{Source: source, Description: description}
+		preview := newPreview(before, append(slices.Clip(before), entry))
+		if !apply {
+			return mcputil.JSONToolResult("Append Source Preview", preview)
+		}
+
+		var res map[string]any
+		if err := client.CustomRequest(&res, http.MethodPost, "/1/security/sources/append", entry, call.Write); err != nil {
+			return nil, fmt.Errorf("failed to append source: %w", err)
+		}
+		preview.Applied = true
+		preview.Result = res
+
+		return mcputil.JSONToolResult("Source Appended", preview)
+	})
+}
@@ -0,0 +1,73 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterDeleteSource registers the delete_source tool with the MCP server.
+func RegisterDeleteSource(mcps *server.MCPServer) {
+	deleteSourceTool := mcp.NewTool(
+		"security_delete_source",
+		mcp.WithDescription("Remove an IP range from the sources allowed to access the application. Returns a before/after preview, and only applies it when apply is true"),
+		mcp.WithString(
+			"source",
+			mcp.Description("IP range to remove, in CIDR notation"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean(
+			"apply",
+			mcp.Description("Apply the change. When false or omitted, only the preview is returned"),
+		),
+	)
+
+	mcps.AddTool(deleteSourceTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		sourceStr, _ := req.Params.Arguments["source"].(string)
+		source, err := validateCIDR(sourceStr)
+		if err != nil {
+			return nil, err
+		}
+		apply, _ := req.Params.Arguments["apply"].(bool)
+
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		before, err := listSources(client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list sources: %w", err)
+		}
+		after := []Source{}
+		for _, s := range before {
+			if s.Source != source {
+				after = append(after, s)
+			}
+		}
+		if len(after) == len(before) {
+			return nil, fmt.Errorf("%s is not in the allowed sources", source)
+		}
+		preview := newPreview(before, after)
+		if !apply {
+			return mcputil.JSONToolResult("Delete Source Preview", preview)
+		}
+
+		var res map[string]any
+		path := "/1/security/sources/" + url.PathEscape(source)
+		if err := client.CustomRequest(&res, http.MethodDelete, path, nil, call.Write); err != nil {
+			return nil, fmt.Errorf("failed to delete source: %w", err)
+		}
+		preview.Applied = true
+		preview.Result = res
+
+		return mcputil.JSONToolResult("Source Deleted", preview)
+	})
+}
@@ -0,0 +1,33 @@
+package security
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterListSources registers the list_sources tool with the MCP server.
+func RegisterListSources(mcps *server.MCPServer) {
+	listSourcesTool := mcp.NewTool(
+		"security_list_sources",
+		mcp.WithDescription("List the IP ranges allowed to access the application. An empty list means every IP address is allowed"),
+	)
+
+	mcps.AddTool(listSourcesTool, func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		sources, err := listSources(client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list sources: %w", err)
+		}
+
+		return mcputil.JSONToolResult("Sources", sources)
+	})
+}
@@ -0,0 +1,68 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/mcp/pkg/algoliautil"
+	"github.com/algolia/mcp/pkg/mcputil"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterReplaceSources registers the replace_sources tool with the MCP server.
+func RegisterReplaceSources(mcps *server.MCPServer) {
+	replaceSourcesTool := mcp.NewTool(
+		"security_replace_sources",
+		mcp.WithDescription("Replace the whole list of IP ranges allowed to access the application. A wrong list can lock out every backend: always review the before/after preview, which is returned unless apply is true"),
+		mcp.WithString(
+			"sources",
+			mcp.Description("The new sources as a JSON array. Example: [{\"source\":\"10.0.0.0/24\",\"description\":\"backend\"},{\"source\":\"203.0.113.7/32\",\"description\":\"CI egress\"}]"),
+			mcp.Required(),
+		),
+		mcp.WithBoolean(
+			"apply",
+			mcp.Description("Apply the change. When false or omitted, only the preview is returned"),
+		),
+	)
+
+	mcps.AddTool(replaceSourcesTool, func(_ context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		sourcesJSON, _ := req.Params.Arguments["sources"].(string)
+		var sources []Source
+		if err := json.Unmarshal([]byte(sourcesJSON), &sources); err != nil {
+			return nil, fmt.Errorf("invalid sources JSON: %w", err)
+		}
+		for i, s := range sources {
+			if _, err := validateCIDR(s.Source); err != nil {
+				return nil, fmt.Errorf("source at index %d: %w", i, err)
+			}
+		}
+		apply, _ := req.Params.Arguments["apply"].(bool)
+
+		client, err := algoliautil.NewClient("ALGOLIA_WRITE_API_KEY")
+		if err != nil {
+			return nil, err
+		}
+
+		before, err := listSources(client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list sources: %w", err)
+		}
+		preview := newPreview(before, sources)
+		if !apply {
+			return mcputil.JSONToolResult("Replace Sources Preview", preview)
+		}
+
+		var res map[string]any
+		if err := client.CustomRequest(&res, http.MethodPut, "/1/security/sources", sources, call.Write); err != nil {
+			return nil, fmt.Errorf("failed to replace sources: %w", err)
+		}
+		preview.Applied = true
+		preview.Result = res
+
+		return mcputil.JSONToolResult("Sources Replaced", preview)
+	})
+}
@@ -0,0 +1,98 @@
+package security
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/call"
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// RegisterAll registers all Security tools with the MCP server.
+func RegisterAll(mcps *server.MCPServer) {
+	// Register all Security tools.
+	RegisterListSources(mcps)
+	RegisterAppendSource(mcps)
+	RegisterReplaceSources(mcps)
+	RegisterDeleteSource(mcps)
+}
+
+// Source is an IP range allowed to access the application.
+type Source struct {
+	Source      string `json:"source"`
+	Description string `json:"description,omitempty"`
+}
+
+// Preview is the allowlist before and after a change.
+type Preview struct {
+	Applied  bool     `json:"applied"`
+	Before   []Source `json:"before"`
+	After    []Source `json:"after"`
+	Added    []string `json:"added,omitempty"`
+	Removed  []string `json:"removed,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+	Result   any      `json:"result,omitempty"`
+}
+
+// listSources returns the sources currently allowed to access the application.
+func listSources(client *search.Client) ([]Source, error) {
+	sources := []Source{}
+	if err := client.CustomRequest(&sources, http.MethodGet, "/1/security/sources", nil, call.Read); err != nil {
+		return nil, err
+	}
+	return sources, nil
+}
+
+// validateCIDR checks that a source is an IPv4 or IPv6 range in CIDR
+// notation, and returns it in its canonical form.
+func validateCIDR(source string) (string, error) {
+	_, network, err := net.ParseCIDR(source)
+	if err != nil {
+		if net.ParseIP(source) != nil {
+			return "", fmt.Errorf("%q is an IP address, not a range in CIDR notation (e.g., %s/32)", source, source)
+		}
+		return "", fmt.Errorf("%q is not a valid range in CIDR notation (e.g., 192.168.1.0/24)", source)
+	}
+	if network.String() != source {
+		return "", fmt.Errorf("%q has host bits set, did you mean %s?", source, network.String())
+	}
+	return source, nil
+}
+
+// newPreview compares two allowlists and warns about risky changes.
+func newPreview(before, after []Source) Preview {
+	p := Preview{Before: before, After: after}
+
+	inBefore := map[string]bool{}
+	for _, s := range before {
+		inBefore[s.Source] = true
+	}
+	inAfter := map[string]bool{}
+	for _, s := range after {
+		if inAfter[s.Source] {
+			p.Warnings = append(p.Warnings, fmt.Sprintf("%s is listed more than once", s.Source))
+		}
+		inAfter[s.Source] = true
+		if !inBefore[s.Source] {
+			p.Added = append(p.Added, s.Source)
+		}
+	}
+	for _, s := range before {
+		if !inAfter[s.Source] {
+			p.Removed = append(p.Removed, s.Source)
+		}
+	}
+
+	switch {
+	case len(after) == 0 && len(before) > 0:
+		p.Warnings = append(p.Warnings, "the allowlist becomes empty, which lifts the restriction: every IP address will be able to access the application")
+	case len(p.Removed) > 0:
+		p.Warnings = append(p.Warnings, fmt.Sprintf("%d source(s) lose access: make sure none of your backends, CI runners or this server still use them", len(p.Removed)))
+	}
+	if len(before) == 0 && len(after) > 0 {
+		p.Warnings = append(p.Warnings, "the allowlist was empty: once applied, only the listed sources will be able to access the application")
+	}
+	return p
+}